@@ -1,13 +1,20 @@
 package app
 
 import (
+	"fmt"
+
 	"yatter-backend-go/app/config"
 	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/repository"
+	"yatter-backend-go/app/search"
+	"yatter-backend-go/app/storage"
 )
 
 // Dependency manager for whole application
 type App struct {
-	Dao dao.Dao
+	Dao     dao.Dao
+	Storage storage.Storage
+	Search  repository.Search
 }
 
 // Create dependency manager
@@ -20,5 +27,45 @@ func NewApp() (*App, error) {
 		return nil, err
 	}
 
-	return &App{Dao: dao}, nil
+	storage, err := newStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	search, err := newSearch(dao)
+	if err != nil {
+		return nil, err
+	}
+
+	return &App{Dao: dao, Storage: storage, Search: search}, nil
+}
+
+func newStorage() (storage.Storage, error) {
+	switch driver := config.StorageDriver(); driver {
+	case config.StorageDriverLocal:
+		return storage.NewLocal(config.MediaDir(), config.MediaBaseURL()), nil
+	case config.StorageDriverS3:
+		return storage.NewS3(storage.S3Config{
+			Endpoint:        config.S3.Endpoint(),
+			Region:          config.S3.Region(),
+			Bucket:          config.S3.Bucket(),
+			AccessKeyID:     config.S3.AccessKeyID(),
+			SecretAccessKey: config.S3.SecretAccessKey(),
+			PublicURL:       config.S3.PublicURL(),
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %s", driver)
+	}
+}
+
+func newSearch(dao dao.Dao) (repository.Search, error) {
+	switch driver := config.SearchDriver(); driver {
+	case config.SearchDriverMySQL:
+		return dao.Search(), nil
+	case config.SearchDriverMemory:
+		// worker が起動してすぐと、その後も定期的にデータベースから作り直す
+		return search.NewIndex(dao), nil
+	default:
+		return nil, fmt.Errorf("unknown search driver: %s", driver)
+	}
 }
@@ -0,0 +1,19 @@
+package config
+
+import (
+	"time"
+)
+
+const (
+	tokenLifetimeKey     = "TOKEN_LIFETIME_SECONDS"
+	defaultTokenLifetime = 7 * 24 * time.Hour
+)
+
+// Read how long an issued access token stays valid
+func TokenLifetime() time.Duration {
+	sec, err := getInt(tokenLifetimeKey)
+	if err != nil || sec <= 0 {
+		return defaultTokenLifetime
+	}
+	return time.Duration(sec) * time.Second
+}
@@ -0,0 +1,16 @@
+package config
+
+const (
+	searchDriverKey    = "SEARCH_DRIVER"
+	SearchDriverMySQL  = "mysql"
+	SearchDriverMemory = "memory"
+)
+
+// Read which index search uses (mysql or memory)
+func SearchDriver() string {
+	v, err := getString(searchDriverKey)
+	if err != nil {
+		return SearchDriverMySQL
+	}
+	return v
+}
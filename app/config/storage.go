@@ -0,0 +1,99 @@
+package config
+
+import (
+	"log"
+)
+
+const (
+	storageDriverKey    = "STORAGE_DRIVER"
+	StorageDriverLocal  = "local"
+	StorageDriverS3     = "s3"
+	mediaDirKey         = "MEDIA_DIR"
+	defaultMediaDir     = ".data/media"
+	mediaBaseURLKey     = "MEDIA_BASE_URL"
+	defaultMediaBaseURL = "/media"
+	defaultS3Region     = "us-east-1"
+)
+
+// Read which storage uploaded files are stored in (local or s3)
+func StorageDriver() string {
+	v, err := getString(storageDriverKey)
+	if err != nil {
+		return StorageDriverLocal
+	}
+	return v
+}
+
+// Read directory uploaded files are stored in
+func MediaDir() string {
+	v, err := getString(mediaDirKey)
+	if err != nil {
+		return defaultMediaDir
+	}
+	return v
+}
+
+// Read URL prefix uploaded files are served from
+func MediaBaseURL() string {
+	v, err := getString(mediaBaseURLKey)
+	if err != nil {
+		return defaultMediaBaseURL
+	}
+	return v
+}
+
+// accessor namespace
+var S3 _s3
+
+type _s3 struct{}
+
+// Read S3 compatible endpoint
+func (_s3) Endpoint() string {
+	v, err := getString("S3_ENDPOINT")
+	if err != nil {
+		log.Fatal(err)
+	}
+	return v
+}
+
+// Read S3 region
+func (_s3) Region() string {
+	v, err := getString("S3_REGION")
+	if err != nil {
+		return defaultS3Region
+	}
+	return v
+}
+
+// Read S3 bucket name
+func (_s3) Bucket() string {
+	v, err := getString("S3_BUCKET")
+	if err != nil {
+		log.Fatal(err)
+	}
+	return v
+}
+
+// Read S3 access key ID
+func (_s3) AccessKeyID() string {
+	v, err := getString("S3_ACCESS_KEY_ID")
+	if err != nil {
+		log.Fatal(err)
+	}
+	return v
+}
+
+// Read S3 secret access key
+func (_s3) SecretAccessKey() string {
+	v, err := getString("S3_SECRET_ACCESS_KEY")
+	if err != nil {
+		log.Fatal(err)
+	}
+	return v
+}
+
+// Read URL prefix objects are served from, empty to use the endpoint
+func (_s3) PublicURL() string {
+	v, _ := getString("S3_PUBLIC_URL")
+	return v
+}
@@ -27,6 +27,15 @@ func (r *account) Create(ctx context.Context, account *object.Account) error {
 	return nil
 }
 
+func (r *account) Update(ctx context.Context, account *object.Account) error {
+	_, err := r.db.ExecContext(ctx, "update account set display_name = ?, avatar = ?, header = ?, note = ?, locked = ? where id = ?", account.DisplayName, account.Avatar, account.Header, account.Note, account.Locked, account.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (r *account) Retrieve(ctx context.Context, username string) (*object.Account, error) {
 	entity := new(object.Account)
 	err := r.db.QueryRowxContext(ctx, "select * from account where username = ?", username).StructScan(entity)
@@ -36,3 +45,45 @@ func (r *account) Retrieve(ctx context.Context, username string) (*object.Accoun
 
 	return entity, nil
 }
+
+func (r *account) RetrieveByID(ctx context.Context, id object.AccountID) (*object.Account, error) {
+	entity := new(object.Account)
+	err := r.db.QueryRowxContext(ctx, "select * from account where id = ?", id).StructScan(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+func (r *account) RetrieveByIDs(ctx context.Context, ids []object.AccountID) ([]*object.Account, error) {
+	var entities []*object.Account
+	if len(ids) == 0 {
+		return entities, nil
+	}
+
+	query, args, err := sqlx.In("select * from account where id in (?)", ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &entities, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (r *account) RetrieveByUsernames(ctx context.Context, usernames []string) ([]*object.Account, error) {
+	var entities []*object.Account
+	if len(usernames) == 0 {
+		return entities, nil
+	}
+
+	query, args, err := sqlx.In("select * from account where username in (?)", usernames)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &entities, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
@@ -92,3 +92,37 @@ func TestAccountRetrieve(t *testing.T) {
 		})
 	}
 }
+
+func TestAccountUpdate(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(1))
+
+	account, err := accountRepo.Retrieve(ctx, "test0")
+	assert.NoError(t, err)
+
+	displayName := "Test User"
+	avatar := "/media/accounts/avatars/1/a.png"
+	account.DisplayName = &displayName
+	account.Avatar = &avatar
+	assert.NoError(t, accountRepo.Update(ctx, account))
+
+	updated, err := accountRepo.RetrieveByID(ctx, account.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, displayName, *updated.DisplayName)
+	assert.Equal(t, avatar, *updated.Avatar)
+}
+
+func TestAccountRetrieveByIDs(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(3))
+
+	accounts, err := accountRepo.RetrieveByIDs(ctx, []uint64{1, 3, 999})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(accounts))
+
+	accounts, err = accountRepo.RetrieveByIDs(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(accounts))
+}
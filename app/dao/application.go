@@ -0,0 +1,43 @@
+package dao
+
+import (
+	"context"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	application struct {
+		db *sqlx.DB
+	}
+)
+
+func NewApplication(db *sqlx.DB) repository.Application {
+	return &application{db: db}
+}
+
+func (r *application) Create(ctx context.Context, app *object.Application) error {
+	res, err := r.db.ExecContext(ctx, "insert into application (name, website, redirect_uri, scopes, client_id, client_secret) values (?, ?, ?, ?, ?, ?)", app.Name, app.Website, app.RedirectURI, app.Scopes, app.ClientID, app.ClientSecret)
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	app.ID = uint64(id)
+	return nil
+}
+
+func (r *application) RetrieveByClientID(ctx context.Context, clientID string) (*object.Application, error) {
+	entity := new(object.Application)
+	err := r.db.QueryRowxContext(ctx, "select * from application where client_id = ?", clientID).StructScan(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,56 @@
+package dao_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"yatter-backend-go/app/domain/object"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplicationCreateAndRetrieve(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+
+	app, err := object.NewApplication("testapp", []string{"https://example.com/callback"}, "read", nil)
+	assert.NoError(t, err)
+	assert.NoError(t, applicationRepo.Create(ctx, app))
+
+	got, err := applicationRepo.RetrieveByClientID(ctx, app.ClientID)
+	assert.NoError(t, err)
+	assert.Equal(t, app.ID, got.ID)
+	assert.True(t, got.HasRedirectURI("https://example.com/callback"))
+
+	_, err = applicationRepo.RetrieveByClientID(ctx, "unknown")
+	assert.Error(t, err)
+}
+
+func TestAuthorizationCodeConsume(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(1))
+
+	app, err := object.NewApplication("testapp", []string{"https://example.com/callback"}, "read", nil)
+	assert.NoError(t, err)
+	assert.NoError(t, applicationRepo.Create(ctx, app))
+
+	code, err := object.NewAuthorizationCode(app, 1, "https://example.com/callback", "read", time.Minute)
+	assert.NoError(t, err)
+	assert.NoError(t, authorizationCodeRepo.Create(ctx, code))
+
+	// a code failing the check is kept for the right client
+	errMismatch := errors.New("mismatch")
+	_, err = authorizationCodeRepo.Consume(ctx, code.Code, func(*object.AuthorizationCode) error { return errMismatch })
+	assert.Equal(t, errMismatch, err)
+
+	accept := func(*object.AuthorizationCode) error { return nil }
+	got, err := authorizationCodeRepo.Consume(ctx, code.Code, accept)
+	assert.NoError(t, err)
+	assert.Equal(t, "read", got.Scopes)
+
+	// a code can be exchanged only once
+	_, err = authorizationCodeRepo.Consume(ctx, code.Code, accept)
+	assert.Error(t, err)
+}
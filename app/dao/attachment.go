@@ -0,0 +1,75 @@
+package dao
+
+import (
+	"context"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	attachment struct {
+		db *sqlx.DB
+	}
+)
+
+func NewAttachment(db *sqlx.DB) repository.Attachment {
+	return &attachment{db: db}
+}
+
+func (r *attachment) Create(ctx context.Context, attachment *object.Attachment) error {
+	res, err := r.db.ExecContext(ctx, "insert into attachment (account_id, type, url, description) values (?, ?, ?, ?)", attachment.AccountID, attachment.Type, attachment.URL, attachment.Description)
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	attachment.ID = uint64(id)
+	return nil
+}
+
+func (r *attachment) Retrieve(ctx context.Context, id uint64) (*object.Attachment, error) {
+	entity := new(object.Attachment)
+	err := r.db.QueryRowxContext(ctx, "select * from attachment where id = ?", id).StructScan(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+func (r *attachment) RetrieveByIDs(ctx context.Context, ids []uint64) ([]*object.Attachment, error) {
+	var entities []*object.Attachment
+	if len(ids) == 0 {
+		return entities, nil
+	}
+
+	query, args, err := sqlx.In("select * from attachment where id in (?) order by id", ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &entities, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (r *attachment) RetrieveByStatusIDs(ctx context.Context, statusIDs []uint64) ([]*object.Attachment, error) {
+	var entities []*object.Attachment
+	if len(statusIDs) == 0 {
+		return entities, nil
+	}
+
+	query, args, err := sqlx.In("select * from attachment where status_id in (?) order by id", statusIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &entities, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
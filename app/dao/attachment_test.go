@@ -0,0 +1,69 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"yatter-backend-go/app/domain/object"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachmentCreateAndRetrieve(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(1))
+
+	attachment := &object.Attachment{AccountID: 1, Type: object.AttachmentTypeImage, URL: "/media/a.png"}
+	assert.NoError(t, attachmentRepo.Create(ctx, attachment))
+
+	got, err := attachmentRepo.Retrieve(ctx, attachment.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "/media/a.png", got.URL)
+	assert.Nil(t, got.StatusID)
+
+	attachments, err := attachmentRepo.RetrieveByIDs(ctx, []uint64{attachment.ID, 999})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(attachments))
+}
+
+func TestStatusCreateWithMedia(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(2))
+
+	mine := &object.Attachment{AccountID: 1, Type: object.AttachmentTypeImage, URL: "/media/a.png"}
+	assert.NoError(t, attachmentRepo.Create(ctx, mine))
+	others := &object.Attachment{AccountID: 2, Type: object.AttachmentTypeImage, URL: "/media/b.png"}
+	assert.NoError(t, attachmentRepo.Create(ctx, others))
+
+	status := &object.Status{AccountId: 1, Content: "with media", MediaAttachments: []*object.Attachment{mine}}
+	assert.NoError(t, statusRepo.Create(ctx, status))
+
+	got, err := attachmentRepo.Retrieve(ctx, mine.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, status.ID, *got.StatusID)
+
+	// 投稿済みのメディアや他人のメディアは添付できない
+	for _, attachment := range []*object.Attachment{mine, others} {
+		err := statusRepo.Create(ctx, &object.Status{AccountId: 1, Content: "reuse", MediaAttachments: []*object.Attachment{attachment}})
+		assert.Error(t, err)
+	}
+}
+
+func TestAttachmentRetrieveByStatusIDs(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(1))
+
+	for i := 0; i < 2; i++ {
+		attachment := &object.Attachment{AccountID: 1, Type: object.AttachmentTypeImage, URL: "/media/a.png"}
+		assert.NoError(t, attachmentRepo.Create(ctx, attachment))
+		status := &object.Status{AccountId: 1, MediaAttachments: []*object.Attachment{attachment}}
+		assert.NoError(t, statusRepo.Create(ctx, status))
+	}
+	assert.NoError(t, attachmentRepo.Create(ctx, &object.Attachment{AccountID: 1, Type: object.AttachmentTypeImage, URL: "/media/b.png"}))
+
+	attachments, err := attachmentRepo.RetrieveByStatusIDs(ctx, []uint64{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(attachments))
+}
@@ -0,0 +1,54 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	authorizationCode struct {
+		db *sqlx.DB
+	}
+)
+
+func NewAuthorizationCode(db *sqlx.DB) repository.AuthorizationCode {
+	return &authorizationCode{db: db}
+}
+
+func (r *authorizationCode) Create(ctx context.Context, code *object.AuthorizationCode) error {
+	_, err := r.db.ExecContext(ctx, "insert into oauth_authorization_code (code, application_id, account_id, redirect_uri, scopes, code_challenge, code_challenge_method, expires_at) values (?, ?, ?, ?, ?, ?, ?, ?)", code.Code, code.ApplicationID, code.AccountID, code.RedirectURI, code.Scopes, code.CodeChallenge, code.CodeChallengeMethod, code.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// 検証に失敗した場合は削除せず、正しいクライアントが引き続き交換できるようにする
+func (r *authorizationCode) Consume(ctx context.Context, code string, verify func(*object.AuthorizationCode) error) (*object.AuthorizationCode, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	entity := new(object.AuthorizationCode)
+	if err := tx.QueryRowxContext(ctx, "select * from oauth_authorization_code where code = ? for update", code).StructScan(entity); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := verify(entity); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if res, err := tx.ExecContext(ctx, "delete from oauth_authorization_code where id = ?", entity.ID); err != nil {
+		tx.Rollback()
+		return nil, err
+	} else if n, _ := res.RowsAffected(); n == 0 {
+		tx.Rollback()
+		return nil, sql.ErrNoRows
+	}
+	return entity, tx.Commit()
+}
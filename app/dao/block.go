@@ -0,0 +1,84 @@
+package dao
+
+import (
+	"context"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	block struct {
+		db *sqlx.DB
+	}
+)
+
+func NewBlock(db *sqlx.DB) repository.Block {
+	return &block{db: db}
+}
+
+func (r *block) Create(ctx context.Context, accountID object.AccountID, targetID object.AccountID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "insert ignore into block (account_id, target_account_id) values (?, ?)", accountID, targetID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	// 双方向のフォローを解除する。フォロー数は実際に削除した分だけ減らす
+	if _, err := deleteRelationship(ctx, tx, accountID, targetID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := deleteRelationship(ctx, tx, targetID, accountID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "delete from follow_request where (account_id = ? and target_account_id = ?) or (account_id = ? and target_account_id = ?)", accountID, targetID, targetID, accountID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *block) Delete(ctx context.Context, accountID object.AccountID, targetID object.AccountID) error {
+	_, err := r.db.ExecContext(ctx, "delete from block where account_id = ? and target_account_id = ?", accountID, targetID)
+	return err
+}
+
+func (r *block) RetrieveByAccountID(ctx context.Context, accountID object.AccountID, max_id, since_id, limit *uint64) ([]*object.Block, error) {
+	var entities []*object.Block
+	query, args := buildQuery("block", "id", []string{"account_id = ?"}, since_id, max_id, limit)
+	args = append([]interface{}{accountID}, args...)
+	if err := r.db.SelectContext(ctx, &entities, query, args...); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (r *block) IsBlockedBetween(ctx context.Context, accountID object.AccountID, targetID object.AccountID) (bool, error) {
+	var count uint64
+	err := r.db.QueryRowxContext(ctx, "select count(*) from block where (account_id = ? and target_account_id = ?) or (account_id = ? and target_account_id = ?)", accountID, targetID, targetID, accountID).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *block) BlockedAccountIDs(ctx context.Context, accountID object.AccountID, targetIDs []object.AccountID) ([]object.AccountID, error) {
+	var ids []object.AccountID
+	if len(targetIDs) == 0 {
+		return ids, nil
+	}
+
+	query, args, err := sqlx.In("select target_account_id from block where account_id = ? and target_account_id in (?)", accountID, targetIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &ids, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,91 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"yatter-backend-go/app/domain/object"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlock(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	defer cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(3))
+	// 1 と 2 は相互フォロー、3 は 1 をフォローしている
+	insertRelationshipDB(t, ctx, []object.Relationship{
+		{FollowingId: 1, FollowerId: 2},
+		{FollowingId: 2, FollowerId: 1},
+		{FollowingId: 3, FollowerId: 1},
+	})
+	assert.NoError(t, followRequestRepo.Create(ctx, 2, 3))
+
+	assert.NoError(t, blockRepo.Create(ctx, 1, 2))
+	// 重ねてブロックしてもエラーにならない
+	assert.NoError(t, blockRepo.Create(ctx, 1, 2))
+
+	for _, pair := range [][2]object.AccountID{{1, 2}, {2, 1}} {
+		following, err := relationshipRepo.IsFollowing(ctx, pair[0], pair[1])
+		assert.NoError(t, err)
+		assert.False(t, following)
+	}
+	account, err := accountRepo.RetrieveByID(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), account.FollowingCount)
+	assert.Equal(t, uint64(1), account.FollowersCount)
+	account, err = accountRepo.RetrieveByID(ctx, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), account.FollowingCount)
+	assert.Equal(t, uint64(0), account.FollowersCount)
+
+	blocked, err := blockRepo.IsBlockedBetween(ctx, 2, 1)
+	assert.NoError(t, err)
+	assert.True(t, blocked)
+	ids, err := blockRepo.BlockedAccountIDs(ctx, 1, []object.AccountID{2, 3})
+	assert.NoError(t, err)
+	assert.Equal(t, []object.AccountID{2}, ids)
+
+	blocks, err := blockRepo.RetrieveByAccountID(ctx, 1, nil, nil, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, blocks, 1) {
+		assert.Equal(t, object.AccountID(2), blocks[0].TargetAccountID)
+	}
+
+	assert.NoError(t, blockRepo.Delete(ctx, 1, 2))
+	blocked, err = blockRepo.IsBlockedBetween(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.False(t, blocked)
+}
+
+func TestBlockedTimelines(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	defer cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(3))
+	// 1 は 3 をフォローしている
+	insertRelationshipDB(t, ctx, []object.Relationship{{FollowingId: 1, FollowerId: 3}})
+
+	blocked := &object.Status{AccountId: 2, Content: "Test Content"}
+	assert.NoError(t, statusRepo.Create(ctx, blocked))
+	other := &object.Status{AccountId: 3, Content: "Test Content"}
+	assert.NoError(t, statusRepo.Create(ctx, other))
+	reblog := &object.Status{AccountId: 3, ReblogOfID: &blocked.ID}
+	assert.NoError(t, statusRepo.Create(ctx, reblog))
+
+	assert.NoError(t, blockRepo.Create(ctx, 1, 2))
+
+	viewerID := object.AccountID(1)
+	public, err := statusRepo.PublicTimeline(ctx, &viewerID, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{other.ID}, statusIDs(public))
+
+	// ブロックしているアカウントの投稿のブーストも流さない
+	home, err := statusRepo.HomeTimeline(ctx, 1, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{other.ID}, statusIDs(home))
+
+	public, err = statusRepo.PublicTimeline(ctx, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []uint64{blocked.ID, other.ID}, statusIDs(public))
+}
@@ -15,6 +15,23 @@ type (
 		Account() repository.Account
 		Status() repository.Status
 		Relationship() repository.Relationship
+		Token() repository.Token
+		Application() repository.Application
+		AuthorizationCode() repository.AuthorizationCode
+		Attachment() repository.Attachment
+		Favourite() repository.Favourite
+		Poll() repository.Poll
+		ScheduledStatus() repository.ScheduledStatus
+		IdempotencyKey() repository.IdempotencyKey
+		Mention() repository.Mention
+		Tag() repository.Tag
+		Trend() repository.Trend
+		Search() repository.Search
+		SearchSource() repository.SearchSource
+		FollowRequest() repository.FollowRequest
+		Block() repository.Block
+		Mute() repository.Mute
+		Notification() repository.Notification
 
 		// Clear all data in DB
 		// This function is "only" used for testing
@@ -63,6 +80,74 @@ func (d *dao) Relationship() repository.Relationship {
 	return NewRelationship(d.db)
 }
 
+func (d *dao) Token() repository.Token {
+	return NewToken(d.db)
+}
+
+func (d *dao) Application() repository.Application {
+	return NewApplication(d.db)
+}
+
+func (d *dao) AuthorizationCode() repository.AuthorizationCode {
+	return NewAuthorizationCode(d.db)
+}
+
+func (d *dao) Attachment() repository.Attachment {
+	return NewAttachment(d.db)
+}
+
+func (d *dao) Favourite() repository.Favourite {
+	return NewFavourite(d.db)
+}
+
+func (d *dao) Poll() repository.Poll {
+	return NewPoll(d.db)
+}
+
+func (d *dao) ScheduledStatus() repository.ScheduledStatus {
+	return NewScheduledStatus(d.db)
+}
+
+func (d *dao) IdempotencyKey() repository.IdempotencyKey {
+	return NewIdempotencyKey(d.db)
+}
+
+func (d *dao) Mention() repository.Mention {
+	return NewMention(d.db)
+}
+
+func (d *dao) Tag() repository.Tag {
+	return NewTag(d.db)
+}
+
+func (d *dao) Trend() repository.Trend {
+	return NewTrend(d.db)
+}
+
+func (d *dao) Search() repository.Search {
+	return NewSearch(d.db)
+}
+
+func (d *dao) SearchSource() repository.SearchSource {
+	return NewSearchSource(d.db)
+}
+
+func (d *dao) FollowRequest() repository.FollowRequest {
+	return NewFollowRequest(d.db)
+}
+
+func (d *dao) Block() repository.Block {
+	return NewBlock(d.db)
+}
+
+func (d *dao) Mute() repository.Mute {
+	return NewMute(d.db)
+}
+
+func (d *dao) Notification() repository.Notification {
+	return NewNotification(d.db)
+}
+
 // 外部キー制約を無効化して全テーブルをクリアする
 // 外部キー制約を無効化した場合、参照先のテーブルのデータを削除する必要がなくなる
 func (d *dao) InitAll() error {
@@ -80,7 +165,7 @@ func (d *dao) InitAll() error {
 		}
 	}()
 
-	for _, table := range []string{"account", "status", "relationship"} {
+	for _, table := range []string{"account", "status", "relationship", "access_token", "application", "oauth_authorization_code", "attachment", "favourite", "status_edit", "poll", "poll_option", "poll_vote", "scheduled_status", "idempotency_key", "mention", "tag", "status_tag", "trend_tag", "trend_status", "follow_request", "block", "mute", "notification"} {
 		if err := d.exec("TRUNCATE TABLE " + table); err != nil {
 			return fmt.Errorf("Can't truncate table "+table+": %w", err)
 		}
@@ -15,6 +15,23 @@ import (
 var accountRepo repository.Account
 var statusRepo repository.Status
 var relationshipRepo repository.Relationship
+var tokenRepo repository.Token
+var applicationRepo repository.Application
+var authorizationCodeRepo repository.AuthorizationCode
+var attachmentRepo repository.Attachment
+var favouriteRepo repository.Favourite
+var pollRepo repository.Poll
+var scheduledStatusRepo repository.ScheduledStatus
+var idempotencyKeyRepo repository.IdempotencyKey
+var mentionRepo repository.Mention
+var tagRepo repository.Tag
+var trendRepo repository.Trend
+var searchRepo repository.Search
+var followRequestRepo repository.FollowRequest
+var blockRepo repository.Block
+var muteRepo repository.Mute
+var searchSourceRepo repository.SearchSource
+var notificationRepo repository.Notification
 var cleanupDB func()
 
 func TestMain(m *testing.M) {
@@ -30,6 +47,23 @@ func TestMain(m *testing.M) {
 		accountRepo = dao.Account()
 		statusRepo = dao.Status()
 		relationshipRepo = dao.Relationship()
+		tokenRepo = dao.Token()
+		applicationRepo = dao.Application()
+		authorizationCodeRepo = dao.AuthorizationCode()
+		attachmentRepo = dao.Attachment()
+		favouriteRepo = dao.Favourite()
+		pollRepo = dao.Poll()
+		scheduledStatusRepo = dao.ScheduledStatus()
+		idempotencyKeyRepo = dao.IdempotencyKey()
+		mentionRepo = dao.Mention()
+		tagRepo = dao.Tag()
+		trendRepo = dao.Trend()
+		searchRepo = dao.Search()
+		searchSourceRepo = dao.SearchSource()
+		followRequestRepo = dao.FollowRequest()
+		blockRepo = dao.Block()
+		muteRepo = dao.Mute()
+		notificationRepo = dao.Notification()
 	}
 
 	os.Exit(m.Run())
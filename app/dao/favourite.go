@@ -0,0 +1,105 @@
+package dao
+
+import (
+	"context"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	favourite struct {
+		db *sqlx.DB
+	}
+)
+
+func NewFavourite(db *sqlx.DB) repository.Favourite {
+	return &favourite{db: db}
+}
+
+// 既にお気に入り済みの場合は何もしない
+func (r *favourite) Create(ctx context.Context, accountID object.AccountID, statusID uint64) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	res, err := tx.ExecContext(ctx, "insert ignore into favourite (account_id, status_id) values (?, ?)", accountID, statusID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		tx.Rollback()
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx, "update status set favourites_count = favourites_count + 1 where id = ?", statusID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := notifyStatusAuthor(ctx, tx, accountID, object.NotificationFavourite, statusID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// お気に入りしていない場合は何もしない
+func (r *favourite) Delete(ctx context.Context, accountID object.AccountID, statusID uint64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	res, err := tx.ExecContext(ctx, "delete from favourite where account_id = ? and status_id = ?", accountID, statusID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		tx.Rollback()
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx, "update status set favourites_count = favourites_count - 1 where id = ?", statusID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *favourite) RetrieveFavouritedBy(ctx context.Context, statusID uint64, limit *uint64) ([]object.Account, error) {
+	var entities []object.Account
+
+	query := `select account.* from account join favourite on account.id = favourite.account_id where favourite.status_id = ?`
+
+	args := []interface{}{statusID}
+
+	query += " order by favourite.create_at desc"
+
+	if limit != nil {
+		query += " limit ?"
+		args = append(args, *limit)
+	}
+
+	err := r.db.SelectContext(ctx, &entities, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities, nil
+}
+
+func (r *favourite) FavouritedStatusIDs(ctx context.Context, accountID object.AccountID, statusIDs []uint64) ([]uint64, error) {
+	var ids []uint64
+	if len(statusIDs) == 0 {
+		return ids, nil
+	}
+
+	query, args, err := sqlx.In("select status_id from favourite where account_id = ? and status_id in (?)", accountID, statusIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &ids, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,42 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"yatter-backend-go/app/domain/object"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFavourite(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(3))
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, statusRepo.Create(ctx, &object.Status{AccountId: 1, Content: "Test Content"}))
+	}
+
+	// 同じアカウントが2回お気に入りしても1回分しか数えない
+	assert.NoError(t, favouriteRepo.Create(ctx, 2, 1))
+	assert.NoError(t, favouriteRepo.Create(ctx, 2, 1))
+	assert.NoError(t, favouriteRepo.Create(ctx, 3, 1))
+
+	status, err := statusRepo.Retrieve(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), status.FavouritesCount)
+
+	accounts, err := favouriteRepo.RetrieveFavouritedBy(ctx, 1, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(accounts))
+
+	ids, err := favouriteRepo.FavouritedStatusIDs(ctx, 2, []uint64{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1}, ids)
+
+	assert.NoError(t, favouriteRepo.Delete(ctx, 2, 1))
+	assert.NoError(t, favouriteRepo.Delete(ctx, 2, 1))
+
+	status, err = statusRepo.Retrieve(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), status.FavouritesCount)
+}
@@ -0,0 +1,84 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	followRequest struct {
+		db *sqlx.DB
+	}
+)
+
+func NewFollowRequest(db *sqlx.DB) repository.FollowRequest {
+	return &followRequest{db: db}
+}
+
+func (r *followRequest) Create(ctx context.Context, accountID object.AccountID, targetID object.AccountID) error {
+	// 同じリクエストが既にあれば何もしない
+	_, err := r.db.ExecContext(ctx, "insert ignore into follow_request (account_id, target_account_id) values (?, ?)", accountID, targetID)
+	return err
+}
+
+func (r *followRequest) Retrieve(ctx context.Context, id uint64) (*object.FollowRequest, error) {
+	entity := new(object.FollowRequest)
+	err := r.db.QueryRowxContext(ctx, "select * from follow_request where id = ?", id).StructScan(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+func (r *followRequest) RetrieveByTargetID(ctx context.Context, targetID object.AccountID, max_id, since_id, limit *uint64) ([]*object.FollowRequest, error) {
+	var entities []*object.FollowRequest
+	query, args := buildQuery("follow_request", "id", []string{"target_account_id = ?"}, since_id, max_id, limit)
+	args = append([]interface{}{targetID}, args...)
+	if err := r.db.SelectContext(ctx, &entities, query, args...); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (r *followRequest) Authorize(ctx context.Context, request *object.FollowRequest) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	res, err := tx.ExecContext(ctx, "delete from follow_request where id = ?", request.ID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	// 同時に承認・拒否された場合は二重にフォローしない
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		tx.Rollback()
+		if err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	if err := createRelationship(ctx, tx, request.AccountID, request.TargetAccountID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *followRequest) Reject(ctx context.Context, id uint64) error {
+	res, err := r.db.ExecContext(ctx, "delete from follow_request where id = ?", id)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package dao_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFollowRequest(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(3))
+
+	assert.NoError(t, followRequestRepo.Create(ctx, 2, 1))
+	// 同じリクエストを重ねて送ってもエラーにならない
+	assert.NoError(t, followRequestRepo.Create(ctx, 2, 1))
+	assert.NoError(t, followRequestRepo.Create(ctx, 3, 1))
+
+	requests, err := followRequestRepo.RetrieveByTargetID(ctx, 1, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, requests, 2)
+
+	relationships, err := relationshipRepo.RetrieveByUsernames(ctx, 2, []string{"test0"})
+	assert.NoError(t, err)
+	if assert.Len(t, relationships, 1) {
+		assert.True(t, relationships[0].Requested)
+		assert.False(t, relationships[0].Following)
+	}
+
+	// 承認されるまではフォローしていない
+	following, err := relationshipRepo.IsFollowing(ctx, 2, 1)
+	assert.NoError(t, err)
+	assert.False(t, following)
+
+	request, err := followRequestRepo.Retrieve(ctx, requests[len(requests)-1].ID)
+	assert.NoError(t, err)
+	assert.NoError(t, followRequestRepo.Authorize(ctx, request))
+	assert.Equal(t, sql.ErrNoRows, followRequestRepo.Authorize(ctx, request))
+
+	following, err = relationshipRepo.IsFollowing(ctx, request.AccountID, 1)
+	assert.NoError(t, err)
+	assert.True(t, following)
+	target, err := accountRepo.RetrieveByID(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), target.FollowersCount)
+
+	rejected := requests[0]
+	if rejected.ID == request.ID {
+		rejected = requests[1]
+	}
+	assert.NoError(t, followRequestRepo.Reject(ctx, rejected.ID))
+	assert.Equal(t, sql.ErrNoRows, followRequestRepo.Reject(ctx, rejected.ID))
+
+	requests, err = followRequestRepo.RetrieveByTargetID(ctx, 1, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, requests, 0)
+}
+
+func TestFollowRequestWithdrawnByUnfollow(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(2))
+
+	// 2 が鍵アカウントの 1 にフォローをリクエストしてから、承認前にフォローを解除する
+	assert.NoError(t, followRequestRepo.Create(ctx, 2, 1))
+	requests, err := followRequestRepo.RetrieveByTargetID(ctx, 1, nil, nil, nil)
+	assert.NoError(t, err)
+	if !assert.Len(t, requests, 1) {
+		return
+	}
+	assert.NoError(t, relationshipRepo.Delete(ctx, 2, 1))
+
+	// 取り下げられたリクエストは承認できない
+	assert.Equal(t, sql.ErrNoRows, followRequestRepo.Authorize(ctx, requests[0]))
+	following, err := relationshipRepo.IsFollowing(ctx, 2, 1)
+	assert.NoError(t, err)
+	assert.False(t, following)
+
+	// 何もない状態でのフォロー解除はエラーになる
+	assert.Error(t, relationshipRepo.Delete(ctx, 2, 1))
+}
@@ -0,0 +1,66 @@
+package dao
+
+import (
+	"context"
+	"time"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	idempotencyKey struct {
+		db *sqlx.DB
+	}
+)
+
+func NewIdempotencyKey(db *sqlx.DB) repository.IdempotencyKey {
+	return &idempotencyKey{db: db}
+}
+
+func (r *idempotencyKey) Reserve(ctx context.Context, accountID object.AccountID, key string, now time.Time) (bool, error) {
+	// 期限切れのキーと、処理中のまま放置されたキーは使い直せる
+	if _, err := r.db.ExecContext(ctx, "delete from idempotency_key where account_id = ? and idempotency_key = ? and (create_at <= ? or (response is null and create_at <= ?))", accountID, key, now.Add(-object.IdempotencyKeyLifetime), now.Add(-object.IdempotencyKeyInFlightTimeout)); err != nil {
+		return false, err
+	}
+
+	// 同時に届いたリクエストのうち、一意制約により1つだけが予約できる
+	res, err := r.db.ExecContext(ctx, "insert ignore into idempotency_key (account_id, idempotency_key, create_at) values (?, ?, ?)", accountID, key, now)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (r *idempotencyKey) Retrieve(ctx context.Context, accountID object.AccountID, key string) (*object.IdempotencyKey, error) {
+	entity := new(object.IdempotencyKey)
+	err := r.db.QueryRowxContext(ctx, "select * from idempotency_key where account_id = ? and idempotency_key = ?", accountID, key).StructScan(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+func (r *idempotencyKey) Complete(ctx context.Context, accountID object.AccountID, key string, response string) error {
+	_, err := r.db.ExecContext(ctx, "update idempotency_key set response = ? where account_id = ? and idempotency_key = ?", response, accountID, key)
+	return err
+}
+
+func (r *idempotencyKey) Release(ctx context.Context, accountID object.AccountID, key string) error {
+	_, err := r.db.ExecContext(ctx, "delete from idempotency_key where account_id = ? and idempotency_key = ? and response is null", accountID, key)
+	return err
+}
+
+func (r *idempotencyKey) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, "delete from idempotency_key where create_at <= ?", now.Add(-object.IdempotencyKeyLifetime))
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
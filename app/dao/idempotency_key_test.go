@@ -0,0 +1,58 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yatter-backend-go/app/domain/object"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(2))
+
+	now := time.Now().Truncate(time.Second)
+
+	reserved, err := idempotencyKeyRepo.Reserve(ctx, 1, "key", now)
+	assert.NoError(t, err)
+	assert.True(t, reserved)
+
+	// 処理中は同じキーを予約できないが、別のアカウントは使える
+	reserved, err = idempotencyKeyRepo.Reserve(ctx, 1, "key", now)
+	assert.NoError(t, err)
+	assert.False(t, reserved)
+	reserved, err = idempotencyKeyRepo.Reserve(ctx, 2, "key", now)
+	assert.NoError(t, err)
+	assert.True(t, reserved)
+
+	entry, err := idempotencyKeyRepo.Retrieve(ctx, 1, "key")
+	assert.NoError(t, err)
+	assert.Nil(t, entry.Response)
+
+	assert.NoError(t, idempotencyKeyRepo.Complete(ctx, 1, "key", `{"id":1}`))
+	// 完了したキーは解放されない
+	assert.NoError(t, idempotencyKeyRepo.Release(ctx, 1, "key"))
+	entry, err = idempotencyKeyRepo.Retrieve(ctx, 1, "key")
+	assert.NoError(t, err)
+	if assert.NotNil(t, entry.Response) {
+		assert.Equal(t, `{"id":1}`, *entry.Response)
+	}
+
+	// 処理中のまま放置されたキーは使い直せる
+	reserved, err = idempotencyKeyRepo.Reserve(ctx, 2, "key", now.Add(object.IdempotencyKeyInFlightTimeout))
+	assert.NoError(t, err)
+	assert.True(t, reserved)
+
+	// 期限が過ぎると使い直せる
+	later := now.Add(object.IdempotencyKeyLifetime)
+	reserved, err = idempotencyKeyRepo.Reserve(ctx, 1, "key", later)
+	assert.NoError(t, err)
+	assert.True(t, reserved)
+
+	n, err := idempotencyKeyRepo.DeleteExpired(ctx, later.Add(object.IdempotencyKeyLifetime))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+}
@@ -0,0 +1,66 @@
+package dao
+
+import (
+	"context"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	mention struct {
+		db *sqlx.DB
+	}
+)
+
+func NewMention(db *sqlx.DB) repository.Mention {
+	return &mention{db: db}
+}
+
+// 投稿の作成・編集と同じトランザクション内でメンションを記録し、メンションされた人に通知する
+// 編集でメンションを作り直しても、同じ人への通知は増えない
+func createMentions(ctx context.Context, tx *sqlx.Tx, status *object.Status) error {
+	for _, m := range status.Mentions {
+		if _, err := tx.ExecContext(ctx, "insert into mention (status_id, account_id) values (?, ?)", status.ID, m.AccountID); err != nil {
+			return err
+		}
+		m.StatusID = status.ID
+		if err := createNotification(ctx, tx, m.AccountID, status.AccountId, object.NotificationMention, &status.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *mention) RetrieveByStatusIDs(ctx context.Context, statusIDs []uint64) ([]*object.Mention, error) {
+	var entities []*object.Mention
+	if len(statusIDs) == 0 {
+		return entities, nil
+	}
+
+	query, args, err := sqlx.In("select mention.status_id, mention.account_id, account.username from mention join account on account.id = mention.account_id where mention.status_id in (?) order by mention.id", statusIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &entities, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (r *mention) MentionedStatusIDs(ctx context.Context, accountID object.AccountID, statusIDs []uint64) ([]uint64, error) {
+	var ids []uint64
+	if len(statusIDs) == 0 {
+		return ids, nil
+	}
+
+	query, args, err := sqlx.In("select status_id from mention where account_id = ? and status_id in (?)", accountID, statusIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &ids, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
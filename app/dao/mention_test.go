@@ -0,0 +1,60 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"yatter-backend-go/app/domain/object"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusMentionsAndTags(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(2))
+
+	mentioned, err := accountRepo.RetrieveByUsernames(ctx, object.ParseMentions("@test1 @nobody"))
+	assert.NoError(t, err)
+	assert.Len(t, mentioned, 1)
+
+	status := &object.Status{AccountId: 1, Visibility: object.VisibilityDirect}
+	status.SetText("@test1 #Go #go #yatter", mentioned)
+	assert.NoError(t, statusRepo.Create(ctx, status))
+
+	// 同じタグは使い回される
+	other := &object.Status{AccountId: 2}
+	other.SetText("#GO", nil)
+	assert.NoError(t, statusRepo.Create(ctx, other))
+
+	mentions, err := mentionRepo.RetrieveByStatusIDs(ctx, []uint64{status.ID, other.ID})
+	assert.NoError(t, err)
+	if assert.Len(t, mentions, 1) {
+		assert.Equal(t, status.ID, mentions[0].StatusID)
+		assert.Equal(t, "test1", mentions[0].Username)
+	}
+
+	ids, err := mentionRepo.MentionedStatusIDs(ctx, 2, []uint64{status.ID, other.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{status.ID}, ids)
+
+	tags, err := tagRepo.RetrieveByStatusIDs(ctx, []uint64{status.ID, other.ID})
+	assert.NoError(t, err)
+	if assert.Len(t, tags, 3) {
+		assert.Equal(t, "go", tags[0].Name)
+		assert.Equal(t, "yatter", tags[1].Name)
+		assert.Equal(t, tags[0].ID, tags[2].ID)
+		assert.Equal(t, other.ID, tags[2].StatusID)
+	}
+
+	// 編集するとメンションとタグが作り直される
+	status.SetText("#yatter only", nil)
+	assert.NoError(t, statusRepo.Update(ctx, status))
+	mentions, err = mentionRepo.RetrieveByStatusIDs(ctx, []uint64{status.ID})
+	assert.NoError(t, err)
+	assert.Len(t, mentions, 0)
+	tags, err = tagRepo.RetrieveByStatusIDs(ctx, []uint64{status.ID})
+	assert.NoError(t, err)
+	if assert.Len(t, tags, 1) {
+		assert.Equal(t, "yatter", tags[0].Name)
+	}
+}
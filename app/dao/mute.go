@@ -0,0 +1,51 @@
+package dao
+
+import (
+	"context"
+	"time"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	mute struct {
+		db *sqlx.DB
+	}
+)
+
+func NewMute(db *sqlx.DB) repository.Mute {
+	return &mute{db: db}
+}
+
+// 期限切れのミュートは削除されるまでの間も効かない
+const activeMute = "(mute.expires_at is null or mute.expires_at > ?)"
+
+func (r *mute) Create(ctx context.Context, m *object.Mute) error {
+	_, err := r.db.ExecContext(ctx, "insert into mute (account_id, target_account_id, hide_notifications, expires_at) values (?, ?, ?, ?) on duplicate key update hide_notifications = values(hide_notifications), expires_at = values(expires_at)", m.AccountID, m.TargetAccountID, m.HideNotifications, m.ExpiresAt)
+	return err
+}
+
+func (r *mute) Delete(ctx context.Context, accountID object.AccountID, targetID object.AccountID) error {
+	_, err := r.db.ExecContext(ctx, "delete from mute where account_id = ? and target_account_id = ?", accountID, targetID)
+	return err
+}
+
+func (r *mute) RetrieveByAccountID(ctx context.Context, accountID object.AccountID, now time.Time, max_id, since_id, limit *uint64) ([]*object.Mute, error) {
+	var entities []*object.Mute
+	query, args := buildQuery("mute", "id", []string{"account_id = ?", activeMute}, since_id, max_id, limit)
+	args = append([]interface{}{accountID, now}, args...)
+	if err := r.db.SelectContext(ctx, &entities, query, args...); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (r *mute) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, "delete from mute where expires_at <= ?", now)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,70 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yatter-backend-go/app/domain/object"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMute(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	defer cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(3))
+	// 1 は 2 と 3 をフォローしている
+	insertRelationshipDB(t, ctx, []object.Relationship{
+		{FollowingId: 1, FollowerId: 2},
+		{FollowingId: 1, FollowerId: 3},
+	})
+
+	muted := &object.Status{AccountId: 2, Content: "Test Content"}
+	assert.NoError(t, statusRepo.Create(ctx, muted))
+	expired := &object.Status{AccountId: 3, Content: "Test Content"}
+	assert.NoError(t, statusRepo.Create(ctx, expired))
+
+	now := time.Now()
+	assert.NoError(t, muteRepo.Create(ctx, object.NewMute(1, 2, true, 0, now)))
+	assert.NoError(t, muteRepo.Create(ctx, object.NewMute(1, 3, false, time.Hour, now)))
+	// 期限を過去にすると、削除される前でも効かなくなる
+	assert.NoError(t, muteRepo.Create(ctx, object.NewMute(1, 3, false, time.Hour, now.Add(-2*time.Hour))))
+
+	mutes, err := muteRepo.RetrieveByAccountID(ctx, 1, now, nil, nil, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, mutes, 1) {
+		assert.Equal(t, object.AccountID(2), mutes[0].TargetAccountID)
+		assert.True(t, mutes[0].HideNotifications)
+		assert.Nil(t, mutes[0].ExpiresAt)
+	}
+
+	// ミュートしてもフォローは解除されない
+	following, err := relationshipRepo.IsFollowing(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.True(t, following)
+	relationships, err := relationshipRepo.RetrieveByUsernames(ctx, 1, []string{"test1", "test2"})
+	assert.NoError(t, err)
+	for _, relationship := range relationships {
+		assert.True(t, relationship.Following)
+		assert.Equal(t, relationship.Username == "test1", relationship.Muting)
+		assert.Equal(t, relationship.Username == "test1", relationship.MutingNotifications)
+	}
+
+	home, err := statusRepo.HomeTimeline(ctx, 1, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{expired.ID}, statusIDs(home))
+	viewerID := object.AccountID(1)
+	public, err := statusRepo.PublicTimeline(ctx, &viewerID, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{expired.ID}, statusIDs(public))
+
+	n, err := muteRepo.DeleteExpired(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	assert.NoError(t, muteRepo.Delete(ctx, 1, 2))
+	home, err = statusRepo.HomeTimeline(ctx, 1, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []uint64{muted.ID, expired.ID}, statusIDs(home))
+}
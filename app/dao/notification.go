@@ -0,0 +1,111 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	notification struct {
+		db *sqlx.DB
+	}
+)
+
+func NewNotification(db *sqlx.DB) repository.Notification {
+	return &notification{db: db}
+}
+
+// 通知のもとになった操作と同じトランザクション内で通知を作成する
+// 自分自身への操作は通知せず、同じ投稿への同じ操作は一度だけ通知する
+// フォローは status_id が null のため、null の代わりに 0 を入れた status_key の一意キーで重複を防ぐ
+// status_id は ON DELETE CASCADE の外部キーの列なので、MySQL 5.7 では生成列の元にできない
+func createNotification(ctx context.Context, tx *sqlx.Tx, accountID object.AccountID, fromAccountID object.AccountID, notificationType string, statusID *uint64) error {
+	if accountID == fromAccountID {
+		return nil
+	}
+	var statusKey uint64
+	if statusID != nil {
+		statusKey = *statusID
+	}
+	_, err := tx.ExecContext(ctx, "insert into notification (account_id, from_account_id, type, status_id, status_key) values (?, ?, ?, ?, ?)", accountID, fromAccountID, notificationType, statusID, statusKey)
+	return ignoreDuplicateNotification(err)
+}
+
+// 投稿者に通知する。投稿者を読み込まずに済むよう、投稿から通知先を選ぶ
+func notifyStatusAuthor(ctx context.Context, tx *sqlx.Tx, fromAccountID object.AccountID, notificationType string, statusID uint64) error {
+	_, err := tx.ExecContext(ctx, "insert into notification (account_id, from_account_id, type, status_id, status_key) select account_id, ?, ?, id, id from status where id = ? and account_id <> ?", fromAccountID, notificationType, statusID, fromAccountID)
+	return ignoreDuplicateNotification(err)
+}
+
+// 通知済みの操作のやり直しは一意キーの重複になるので成功とみなす
+// insert ignore と違い、外部キー違反などほかのエラーはそのまま返す
+func ignoreDuplicateNotification(err error) error {
+	if isDuplicateEntry(err) {
+		return nil
+	}
+	return err
+}
+
+func (r *notification) Retrieve(ctx context.Context, id uint64) (*object.Notification, error) {
+	entity := new(object.Notification)
+	err := r.db.QueryRowxContext(ctx, "select * from notification where id = ?", id).StructScan(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+// ブロックしているか、通知も含めてミュートしているアカウントからの通知は返さない
+func (r *notification) RetrieveByAccountID(ctx context.Context, accountID object.AccountID, filter object.NotificationFilter, max_id, since_id, limit *uint64) ([]*object.Notification, error) {
+	var entities []*object.Notification
+
+	conditions := []string{
+		"account_id = ?",
+		"from_account_id not in (select target_account_id from block where account_id = ?" +
+			" union select target_account_id from mute where account_id = ? and hide_notifications = 1 and " + activeMute + ")",
+	}
+	args := []interface{}{accountID, accountID, accountID, time.Now()}
+	if len(filter.Types) > 0 {
+		conditions = append(conditions, "type in (?)")
+		args = append(args, filter.Types)
+	}
+	if len(filter.ExcludeTypes) > 0 {
+		conditions = append(conditions, "type not in (?)")
+		args = append(args, filter.ExcludeTypes)
+	}
+	query, pageArgs := buildQuery("notification", "id", conditions, since_id, max_id, limit)
+
+	query, args, err := sqlx.In(query, append(args, pageArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &entities, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (r *notification) Clear(ctx context.Context, accountID object.AccountID) error {
+	_, err := r.db.ExecContext(ctx, "delete from notification where account_id = ?", accountID)
+	return err
+}
+
+// 他人の通知は存在しないものとして扱う
+func (r *notification) Dismiss(ctx context.Context, accountID object.AccountID, id uint64) error {
+	res, err := r.db.ExecContext(ctx, "delete from notification where id = ? and account_id = ?", id, accountID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package dao_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+	"yatter-backend-go/app/domain/object"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func notificationTypes(notifications []*object.Notification) []string {
+	types := make([]string, len(notifications))
+	for i, n := range notifications {
+		types[i] = n.Type
+	}
+	return types
+}
+
+func TestNotification(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	defer cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(3))
+
+	// 2 が 1 をフォローし、フォローし直しても通知は増えない
+	assert.NoError(t, relationshipRepo.Create(ctx, 2, 1))
+	assert.NoError(t, relationshipRepo.Delete(ctx, 2, 1))
+	assert.NoError(t, relationshipRepo.Create(ctx, 2, 1))
+
+	// 3 が 1 と自分をメンションし、編集しても通知は増えない
+	mention := &object.Status{AccountId: 3, Content: "Test Content", Mentions: []*object.Mention{{AccountID: 1}, {AccountID: 3}}}
+	assert.NoError(t, statusRepo.Create(ctx, mention))
+	mention.Content = "Edited Content"
+	assert.NoError(t, statusRepo.Update(ctx, mention))
+
+	// 2 がお気に入りし直しても、1 自身がお気に入りしても通知は増えない
+	status := &object.Status{AccountId: 1, Content: "Test Content"}
+	assert.NoError(t, statusRepo.Create(ctx, status))
+	assert.NoError(t, favouriteRepo.Create(ctx, 2, status.ID))
+	assert.NoError(t, favouriteRepo.Delete(ctx, 2, status.ID))
+	assert.NoError(t, favouriteRepo.Create(ctx, 2, status.ID))
+	assert.NoError(t, favouriteRepo.Create(ctx, 1, status.ID))
+	assert.NoError(t, statusRepo.Create(ctx, &object.Status{AccountId: 3, ReblogOfID: &status.ID}))
+
+	notifications, err := notificationRepo.RetrieveByAccountID(ctx, 1, object.NotificationFilter{}, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"follow", "mention", "favourite", "reblog"}, notificationTypes(notifications))
+	for _, n := range notifications {
+		if n.Type == object.NotificationMention {
+			assert.Equal(t, object.AccountID(3), n.FromAccountID)
+			assert.Equal(t, mention.ID, *n.StatusID)
+		}
+	}
+	notifications, err = notificationRepo.RetrieveByAccountID(ctx, 3, object.NotificationFilter{}, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, notifications)
+
+	notifications, err = notificationRepo.RetrieveByAccountID(ctx, 1, object.NotificationFilter{Types: []string{"favourite", "reblog"}, ExcludeTypes: []string{"reblog"}}, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"favourite"}, notificationTypes(notifications))
+
+	// 通知もミュートしたアカウントからの通知は返さない
+	assert.NoError(t, muteRepo.Create(ctx, object.NewMute(1, 3, true, 0, time.Now())))
+	notifications, err = notificationRepo.RetrieveByAccountID(ctx, 1, object.NotificationFilter{}, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"follow", "favourite"}, notificationTypes(notifications))
+
+	// 他人の通知は消せない
+	follow := notifications[0]
+	if follow.Type != object.NotificationFollow {
+		follow = notifications[1]
+	}
+	assert.Equal(t, sql.ErrNoRows, notificationRepo.Dismiss(ctx, 2, follow.ID))
+	assert.NoError(t, notificationRepo.Dismiss(ctx, 1, follow.ID))
+	assert.Equal(t, sql.ErrNoRows, notificationRepo.Dismiss(ctx, 1, follow.ID))
+
+	assert.NoError(t, notificationRepo.Clear(ctx, 1))
+	assert.NoError(t, muteRepo.Delete(ctx, 1, 3))
+	notifications, err = notificationRepo.RetrieveByAccountID(ctx, 1, object.NotificationFilter{}, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, notifications)
+}
@@ -0,0 +1,183 @@
+package dao
+
+import (
+	"context"
+	"time"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	poll struct {
+		db *sqlx.DB
+	}
+)
+
+func NewPoll(db *sqlx.DB) repository.Poll {
+	return &poll{db: db}
+}
+
+// 投稿と同じトランザクションで投票を作成する
+func createPoll(ctx context.Context, tx *sqlx.Tx, status *object.Status) error {
+	p := status.Poll
+	p.AccountID = status.AccountId
+	p.StatusID = status.ID
+	res, err := tx.ExecContext(ctx, "insert into poll (account_id, status_id, expires_at, multiple, hide_totals) values (?, ?, ?, ?, ?)", p.AccountID, p.StatusID, p.ExpiresAt, p.Multiple, p.HideTotals)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	p.ID = uint64(id)
+
+	for _, option := range p.Options {
+		option.PollID = p.ID
+		if _, err := tx.ExecContext(ctx, "insert into poll_option (poll_id, position, title) values (?, ?, ?)", option.PollID, option.Position, option.Title); err != nil {
+			return err
+		}
+	}
+
+	status.PollID = &p.ID
+	_, err = tx.ExecContext(ctx, "update status set poll_id = ? where id = ?", p.ID, status.ID)
+	return err
+}
+
+func (r *poll) Retrieve(ctx context.Context, id uint64) (*object.Poll, error) {
+	entity := new(object.Poll)
+	err := r.db.QueryRowxContext(ctx, "select * from poll where id = ?", id).StructScan(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.fillOptions(ctx, []*object.Poll{entity}); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (r *poll) RetrieveByIDs(ctx context.Context, ids []uint64) ([]*object.Poll, error) {
+	var entities []*object.Poll
+	if len(ids) == 0 {
+		return entities, nil
+	}
+
+	query, args, err := sqlx.In("select * from poll where id in (?)", ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &entities, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+
+	if err := r.fillOptions(ctx, entities); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (r *poll) fillOptions(ctx context.Context, polls []*object.Poll) error {
+	if len(polls) == 0 {
+		return nil
+	}
+	ids := make([]uint64, len(polls))
+	for i, p := range polls {
+		ids[i] = p.ID
+	}
+
+	var options []*object.PollOption
+	query, args, err := sqlx.In("select * from poll_option where poll_id in (?) order by poll_id, position", ids)
+	if err != nil {
+		return err
+	}
+	if err := r.db.SelectContext(ctx, &options, r.db.Rebind(query), args...); err != nil {
+		return err
+	}
+
+	optionsByPollID := make(map[uint64][]*object.PollOption)
+	for _, option := range options {
+		optionsByPollID[option.PollID] = append(optionsByPollID[option.PollID], option)
+	}
+	for _, p := range polls {
+		p.Options = optionsByPollID[p.ID]
+	}
+	return nil
+}
+
+func (r *poll) Vote(ctx context.Context, id uint64, accountID object.AccountID, choices []int, now time.Time) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// 締め切りと集計を同時に更新されないよう行ロックを取る
+	entity := new(object.Poll)
+	if err := tx.QueryRowxContext(ctx, "select * from poll where id = ? for update", id).StructScan(entity); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if entity.IsExpired(now) {
+		tx.Rollback()
+		return repository.ErrPollExpired
+	}
+
+	var voted bool
+	if err := tx.QueryRowxContext(ctx, "select exists (select 1 from poll_vote where poll_id = ? and account_id = ?)", id, accountID).Scan(&voted); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if voted {
+		tx.Rollback()
+		return repository.ErrAlreadyVoted
+	}
+
+	for _, choice := range choices {
+		if _, err := tx.ExecContext(ctx, "insert into poll_vote (poll_id, account_id, choice) values (?, ?, ?)", id, accountID, choice); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "update poll_option set votes_count = votes_count + 1 where poll_id = ? and position = ?", id, choice); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "update poll set votes_count = votes_count + ?, voters_count = voters_count + 1 where id = ?", len(choices), id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *poll) OwnVotes(ctx context.Context, accountID object.AccountID, pollIDs []uint64) (map[uint64][]int, error) {
+	votes := make(map[uint64][]int)
+	if len(pollIDs) == 0 {
+		return votes, nil
+	}
+
+	var rows []struct {
+		PollID uint64 `db:"poll_id"`
+		Choice int
+	}
+	query, args, err := sqlx.In("select poll_id, choice from poll_vote where account_id = ? and poll_id in (?) order by poll_id, choice", accountID, pollIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &rows, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		votes[row.PollID] = append(votes[row.PollID], row.Choice)
+	}
+	return votes, nil
+}
+
+func (r *poll) CloseExpired(ctx context.Context, now time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, "update poll set expired = 1 where expired = 0 and expires_at <= ?", now)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
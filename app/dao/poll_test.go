@@ -0,0 +1,63 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoll(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(3))
+
+	now := time.Now()
+	poll, err := object.NewPoll([]string{"yes", "no", "maybe"}, time.Hour, true, false, now)
+	assert.NoError(t, err)
+	status := &object.Status{AccountId: 1, Content: "Test Content", Poll: poll}
+	assert.NoError(t, statusRepo.Create(ctx, status))
+
+	got, err := statusRepo.Retrieve(ctx, status.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, &poll.ID, got.PollID)
+
+	assert.NoError(t, pollRepo.Vote(ctx, poll.ID, 2, []int{0, 2}, now))
+	assert.NoError(t, pollRepo.Vote(ctx, poll.ID, 3, []int{0}, now))
+	// 同じアカウントは一度しか投票できない
+	assert.Equal(t, repository.ErrAlreadyVoted, pollRepo.Vote(ctx, poll.ID, 2, []int{1}, now))
+
+	retrieved, err := pollRepo.Retrieve(ctx, poll.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), retrieved.VotesCount)
+	assert.Equal(t, uint64(2), retrieved.VotersCount)
+	if assert.Len(t, retrieved.Options, 3) {
+		assert.Equal(t, uint64(2), *retrieved.Options[0].VotesCount)
+		assert.Equal(t, uint64(0), *retrieved.Options[1].VotesCount)
+		assert.Equal(t, uint64(1), *retrieved.Options[2].VotesCount)
+	}
+
+	votes, err := pollRepo.OwnVotes(ctx, 2, []uint64{poll.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 2}, votes[poll.ID])
+
+	// 締め切り後は投票できず、ワーカーが終了にする
+	later := now.Add(2 * time.Hour)
+	assert.Equal(t, repository.ErrPollExpired, pollRepo.Vote(ctx, poll.ID, 1, []int{1}, later))
+	n, err := pollRepo.CloseExpired(ctx, later)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	polls, err := pollRepo.RetrieveByIDs(ctx, []uint64{poll.ID})
+	assert.NoError(t, err)
+	if assert.Len(t, polls, 1) {
+		assert.True(t, polls[0].Expired)
+	}
+
+	// 投稿を消すと投票も消える
+	assert.NoError(t, statusRepo.Delete(ctx, status.ID))
+	_, err = pollRepo.Retrieve(ctx, poll.ID)
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package dao
 import (
 	"context"
 	"fmt"
+	"time"
 	"yatter-backend-go/app/domain/object"
 	"yatter-backend-go/app/domain/repository"
 
@@ -23,49 +24,79 @@ func NewRelationship(db *sqlx.DB) repository.Relationship {
 // follower フォローされる人
 
 func (r *relationship) Create(ctx context.Context, followingID object.AccountID, followerID object.AccountID) error {
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
-	if _, err := tx.ExecContext(ctx, "insert into relationship (following_id, follower_id) values (?, ?)", followingID, followerID); err != nil {
+	if err := createRelationship(ctx, tx, followingID, followerID); err != nil {
 		tx.Rollback()
 		return err
 	}
+	return tx.Commit()
+}
+
+// relationship を作成し、双方のフォロー数を更新してフォローされた人に通知する
+func createRelationship(ctx context.Context, tx *sqlx.Tx, followingID object.AccountID, followerID object.AccountID) error {
+	if _, err := tx.ExecContext(ctx, "insert into relationship (following_id, follower_id) values (?, ?)", followingID, followerID); err != nil {
+		return err
+	}
 	if _, err := tx.ExecContext(ctx, "update account set following_count = following_count + 1 where id = ?", followingID); err != nil {
-		tx.Rollback()
 		return err
 	}
 	if _, err := tx.ExecContext(ctx, "update account set followers_count = followers_count + 1 where id = ?", followerID); err != nil {
-		tx.Rollback()
 		return err
 	}
-	return tx.Commit()
+	return createNotification(ctx, tx, followerID, followingID, object.NotificationFollow, nil)
 }
 
 func (r *relationship) Delete(ctx context.Context, followingID object.AccountID, followerID object.AccountID) error {
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
-	if res, err := tx.ExecContext(ctx, "delete from relationship where following_id = ? and follower_id = ?", followingID, followerID); err != nil {
+	deleted, err := deleteRelationship(ctx, tx, followingID, followerID)
+	if err != nil {
 		tx.Rollback()
 		return err
-	} else {
-		if res, _ := res.RowsAffected(); res == 0 {
-			return fmt.Errorf("not found")
-		}
 	}
-	if _, err := tx.ExecContext(ctx, "update account set following_count = following_count - 1 where id = ?", followingID); err != nil {
+	// 承認待ちのフォローリクエストも取り下げ、後から承認されないようにする
+	res, err := tx.ExecContext(ctx, "delete from follow_request where account_id = ? and target_account_id = ?", followingID, followerID)
+	if err != nil {
 		tx.Rollback()
 		return err
 	}
-	if _, err := tx.ExecContext(ctx, "update account set followers_count = followers_count - 1 where id = ?", followerID); err != nil {
+	withdrawn, err := res.RowsAffected()
+	if err != nil {
 		tx.Rollback()
 		return err
 	}
+	if !deleted && withdrawn == 0 {
+		tx.Rollback()
+		return fmt.Errorf("not found")
+	}
 	return tx.Commit()
 }
 
+// relationship があれば削除し、双方のフォロー数を更新する
+func deleteRelationship(ctx context.Context, tx *sqlx.Tx, followingID object.AccountID, followerID object.AccountID) (bool, error) {
+	res, err := tx.ExecContext(ctx, "delete from relationship where following_id = ? and follower_id = ?", followingID, followerID)
+	if err != nil {
+		return false, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return false, err
+	} else if n == 0 {
+		return false, nil
+	}
+	if _, err := tx.ExecContext(ctx, "update account set following_count = following_count - 1 where id = ?", followingID); err != nil {
+		return false, err
+	}
+	if _, err := tx.ExecContext(ctx, "update account set followers_count = followers_count - 1 where id = ?", followerID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (r *relationship) Retrieve(ctx context.Context, accountID object.AccountID) ([]object.Relationship, error) {
 	var entities []object.Relationship
 
@@ -90,6 +121,43 @@ func (r *relationship) Retrieve(ctx context.Context, accountID object.AccountID)
 	return entities, nil
 }
 
+// RetrieveByUsernames usernames の各アカウントと accountID の関係を一度のクエリで取得する
+func (r *relationship) RetrieveByUsernames(ctx context.Context, accountID object.AccountID, usernames []string) ([]*object.AccountRelationship, error) {
+	var entities []*object.AccountRelationship
+	if len(usernames) == 0 {
+		return entities, nil
+	}
+	now := time.Now()
+
+	query, args, err := sqlx.In(
+		"select account.id, account.username,"+
+			" exists (select 1 from relationship where following_id = ? and follower_id = account.id) as following,"+
+			" exists (select 1 from relationship where following_id = account.id and follower_id = ?) as followed_by,"+
+			" exists (select 1 from follow_request where account_id = ? and target_account_id = account.id) as requested,"+
+			" exists (select 1 from block where account_id = ? and target_account_id = account.id) as blocking,"+
+			" exists (select 1 from mute where account_id = ? and target_account_id = account.id and "+activeMute+") as muting,"+
+			" exists (select 1 from mute where account_id = ? and target_account_id = account.id and hide_notifications = 1 and "+activeMute+") as muting_notifications"+
+			" from account where account.username in (?)",
+		accountID, accountID, accountID, accountID, accountID, now, accountID, now, usernames)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &entities, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (r *relationship) IsFollowing(ctx context.Context, followingID object.AccountID, followerID object.AccountID) (bool, error) {
+	var count uint64
+	err := r.db.QueryRowxContext(ctx, "select count(*) from relationship where following_id = ? and follower_id = ?", followingID, followerID).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
 func (r *relationship) RetrieveFollowing(ctx context.Context, accountID object.AccountID, limit *uint64) ([]object.Account, error) {
 	var entities []object.Account
 
@@ -119,7 +187,10 @@ func (r *relationship) RetrieveFollowers(ctx context.Context, accountID object.A
 
 	args := []interface{}{accountID}
 
-	// TODO only_media
+	// メディア付きの投稿をしたことがあるアカウントに絞る
+	if isTrue(only_media) {
+		query += " and exists (select 1 from status where status.account_id = account.id and status.has_media = 1)"
+	}
 
 	if max_id != nil {
 		query += " and account.id <= ?"
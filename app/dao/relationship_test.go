@@ -102,6 +102,36 @@ func TestRelationshipRetrieve(t *testing.T) {
 	assert.Equal(t, 2, len(relationships))
 }
 
+func TestRelationshipRetrieveByUsernames(t *testing.T) {
+	cleanupDB()
+	ctx := context.Background()
+	insertAccountDB(t, ctx, createAccountObject(3))
+	insertRelationshipDB(t, ctx, []object.Relationship{
+		{
+			FollowingId: 1,
+			FollowerId:  2,
+		},
+		{
+			FollowingId: 3,
+			FollowerId:  1,
+		},
+	})
+
+	relationships, err := relationshipRepo.RetrieveByUsernames(ctx, 1, []string{"test1", "test2", "unknown"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(relationships))
+	for _, relationship := range relationships {
+		switch relationship.Username {
+		case "test1":
+			assert.True(t, relationship.Following)
+			assert.False(t, relationship.FollowedBy)
+		case "test2":
+			assert.False(t, relationship.Following)
+			assert.True(t, relationship.FollowedBy)
+		}
+	}
+}
+
 func TestRetrieveFollowing(t *testing.T) {
 	cleanupDB()
 	ctx := context.Background()
@@ -191,3 +221,23 @@ func TestCountFollowers(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, uint64(2), count)
 }
+
+func TestIsFollowing(t *testing.T) {
+	cleanupDB()
+	ctx := context.Background()
+	insertAccountDB(t, ctx, createAccountObject(2))
+	insertRelationshipDB(t, ctx, []object.Relationship{
+		{
+			FollowingId: 1,
+			FollowerId:  2,
+		},
+	})
+
+	following, err := relationshipRepo.IsFollowing(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.True(t, following)
+
+	following, err = relationshipRepo.IsFollowing(ctx, 2, 1)
+	assert.NoError(t, err)
+	assert.False(t, following)
+}
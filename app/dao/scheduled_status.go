@@ -0,0 +1,151 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	scheduledStatus struct {
+		db *sqlx.DB
+	}
+)
+
+func NewScheduledStatus(db *sqlx.DB) repository.ScheduledStatus {
+	return &scheduledStatus{db: db}
+}
+
+func (r *scheduledStatus) Create(ctx context.Context, scheduled *object.ScheduledStatus) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	res, err := tx.ExecContext(ctx, "insert into scheduled_status (account_id, scheduled_at, params) values (?, ?, ?)", scheduled.AccountID, scheduled.ScheduledAt, scheduled.Params)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	scheduled.ID = uint64(id)
+
+	if len(scheduled.Params.MediaIDs) > 0 {
+		// 公開されるまで他の投稿に使われないようメディアを確保しておく
+		query, args, err := sqlx.In("update attachment set scheduled_status_id = ? where id in (?) and account_id = ? and status_id is null and scheduled_status_id is null", scheduled.ID, scheduled.Params.MediaIDs, scheduled.AccountID)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		res, err := tx.ExecContext(ctx, tx.Rebind(query), args...)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if n, _ := res.RowsAffected(); n != int64(len(scheduled.Params.MediaIDs)) {
+			tx.Rollback()
+			return fmt.Errorf("media attachments are not available")
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *scheduledStatus) Retrieve(ctx context.Context, id uint64) (*object.ScheduledStatus, error) {
+	entity := new(object.ScheduledStatus)
+	err := r.db.QueryRowxContext(ctx, "select * from scheduled_status where id = ?", id).StructScan(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+func (r *scheduledStatus) RetrieveByAccountID(ctx context.Context, accountID object.AccountID, limit *uint64) ([]*object.ScheduledStatus, error) {
+	var entities []*object.ScheduledStatus
+
+	query := "select * from scheduled_status where account_id = ? order by scheduled_at, id"
+	args := []interface{}{accountID}
+
+	if limit != nil {
+		query += " limit ?"
+		args = append(args, *limit)
+	}
+
+	if err := r.db.SelectContext(ctx, &entities, query, args...); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+// 公開処理中のリースを解放し、古い時刻で公開されないようにする
+// 公開に失敗していた場合も、予約し直せば再び公開を試みる
+func (r *scheduledStatus) Reschedule(ctx context.Context, id uint64, scheduledAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, "update scheduled_status set scheduled_at = ?, lease_token = null, lease_expires_at = null, failure = null where id = ?", scheduledAt, id)
+	return err
+}
+
+// 確保していたメディアは外部キーで解放される
+func (r *scheduledStatus) Delete(ctx context.Context, id uint64) error {
+	_, err := r.db.ExecContext(ctx, "delete from scheduled_status where id = ?", id)
+	return err
+}
+
+// 複数のサーバーが同時に動いても、行の更新は排他されるため同じ予約投稿を二重に取得しない
+func (r *scheduledStatus) Claim(ctx context.Context, token string, now time.Time, leaseExpiresAt time.Time, limit int) ([]*object.ScheduledStatus, error) {
+	if _, err := r.db.ExecContext(ctx, "update scheduled_status set lease_token = ?, lease_expires_at = ? where scheduled_at <= ? and failure is null and (lease_expires_at is null or lease_expires_at <= ?) order by scheduled_at, id limit ?", token, leaseExpiresAt, now, now, limit); err != nil {
+		return nil, err
+	}
+
+	var entities []*object.ScheduledStatus
+	if err := r.db.SelectContext(ctx, &entities, "select * from scheduled_status where lease_token = ? order by scheduled_at, id", token); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (r *scheduledStatus) Publish(ctx context.Context, scheduled *object.ScheduledStatus, status *object.Status, now time.Time) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	// リースが切れて他のサーバーに取られた場合や、取り消し・変更された場合は公開しない
+	res, err := tx.ExecContext(ctx, "delete from scheduled_status where id = ? and lease_token = ? and lease_expires_at > ?", scheduled.ID, scheduled.LeaseToken, now)
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	if err := createStatus(ctx, tx, status); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// 再試行しても公開できない予約投稿は、取り消されるか予約し直されるまで取得しない
+func (r *scheduledStatus) Fail(ctx context.Context, scheduled *object.ScheduledStatus, failure string, now time.Time) (bool, error) {
+	if runes := []rune(failure); len(runes) > 255 {
+		failure = string(runes[:255])
+	}
+	res, err := r.db.ExecContext(ctx, "update scheduled_status set failure = ?, lease_token = null, lease_expires_at = null where id = ? and lease_token = ? and lease_expires_at > ?", failure, scheduled.ID, scheduled.LeaseToken, now)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
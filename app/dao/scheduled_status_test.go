@@ -0,0 +1,119 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yatter-backend-go/app/domain/object"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduledStatus(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+
+	attachment := &object.Attachment{AccountID: 1, Type: object.AttachmentTypeImage, URL: "/media/a.png"}
+	assert.NoError(t, attachmentRepo.Create(ctx, attachment))
+
+	now := time.Now().Truncate(time.Second)
+	scheduled := &object.ScheduledStatus{
+		AccountID:   1,
+		ScheduledAt: object.DateTime{Time: now.Add(time.Hour)},
+		Params:      object.ScheduledStatusParams{Text: "scheduled", MediaIDs: []uint64{attachment.ID}, Visibility: object.VisibilityPublic},
+	}
+	assert.NoError(t, scheduledStatusRepo.Create(ctx, scheduled))
+
+	// 予約中のメディアは他の投稿に使えない
+	reserved, err := attachmentRepo.Retrieve(ctx, attachment.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, &scheduled.ID, reserved.ScheduledStatusID)
+	assert.Error(t, statusRepo.Create(ctx, &object.Status{AccountId: 1, Content: "other", MediaAttachments: []*object.Attachment{reserved}}))
+
+	list, err := scheduledStatusRepo.RetrieveByAccountID(ctx, 1, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, list, 1) {
+		assert.Equal(t, "scheduled", list[0].Params.Text)
+	}
+
+	// 期限前は取得されない
+	claimed, err := scheduledStatusRepo.Claim(ctx, "early", now, now.Add(time.Minute), 10)
+	assert.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	due := now.Add(2 * time.Hour)
+	claimed, err = scheduledStatusRepo.Claim(ctx, "first", due, due.Add(time.Minute), 10)
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+
+	// リース中は他のサーバーが取得できない
+	other, err := scheduledStatusRepo.Claim(ctx, "second", due, due.Add(time.Minute), 10)
+	assert.NoError(t, err)
+	assert.Empty(t, other)
+
+	status := &object.Status{AccountId: 1, Content: "scheduled", MediaAttachments: []*object.Attachment{attachment}}
+	published, err := scheduledStatusRepo.Publish(ctx, claimed[0], status, due)
+	assert.NoError(t, err)
+	assert.True(t, published)
+
+	_, err = scheduledStatusRepo.Retrieve(ctx, scheduled.ID)
+	assert.Error(t, err)
+	posted, err := attachmentRepo.Retrieve(ctx, attachment.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, &status.ID, posted.StatusID)
+	assert.Nil(t, posted.ScheduledStatusID)
+}
+
+func TestScheduledStatusRescheduleReleasesLease(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+
+	now := time.Now().Truncate(time.Second)
+	scheduled := &object.ScheduledStatus{AccountID: 1, ScheduledAt: object.DateTime{Time: now}, Params: object.ScheduledStatusParams{Text: "scheduled"}}
+	assert.NoError(t, scheduledStatusRepo.Create(ctx, scheduled))
+
+	claimed, err := scheduledStatusRepo.Claim(ctx, "first", now, now.Add(time.Minute), 10)
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+
+	assert.NoError(t, scheduledStatusRepo.Reschedule(ctx, scheduled.ID, now.Add(time.Hour)))
+
+	published, err := scheduledStatusRepo.Publish(ctx, claimed[0], &object.Status{AccountId: 1, Content: "scheduled"}, now)
+	assert.NoError(t, err)
+	assert.False(t, published)
+}
+
+func TestScheduledStatusFail(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+
+	now := time.Now().Truncate(time.Second)
+	scheduled := &object.ScheduledStatus{AccountID: 1, ScheduledAt: object.DateTime{Time: now}, Params: object.ScheduledStatusParams{Text: "scheduled"}}
+	assert.NoError(t, scheduledStatusRepo.Create(ctx, scheduled))
+
+	claimed, err := scheduledStatusRepo.Claim(ctx, "first", now, now.Add(time.Minute), 10)
+	assert.NoError(t, err)
+	if !assert.Len(t, claimed, 1) {
+		return
+	}
+	failed, err := scheduledStatusRepo.Fail(ctx, claimed[0], "media attachments were deleted", now)
+	assert.NoError(t, err)
+	assert.True(t, failed)
+
+	// 失敗した予約投稿はリースが切れた後も取得されない
+	later := now.Add(2 * time.Minute)
+	claimed, err = scheduledStatusRepo.Claim(ctx, "second", later, later.Add(time.Minute), 10)
+	assert.NoError(t, err)
+	assert.Empty(t, claimed)
+	retrieved, err := scheduledStatusRepo.Retrieve(ctx, scheduled.ID)
+	assert.NoError(t, err)
+	if assert.NotNil(t, retrieved.Failure) {
+		assert.Equal(t, "media attachments were deleted", *retrieved.Failure)
+	}
+
+	// 予約し直すと再び公開を試みる
+	assert.NoError(t, scheduledStatusRepo.Reschedule(ctx, scheduled.ID, now))
+	claimed, err = scheduledStatusRepo.Claim(ctx, "third", later, later.Add(time.Minute), 10)
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+}
@@ -0,0 +1,114 @@
+package dao
+
+import (
+	"context"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	search struct {
+		db *sqlx.DB
+	}
+)
+
+// MySQL の FULLTEXT インデックス (ngram パーサ) で検索する
+func NewSearch(db *sqlx.DB) repository.Search {
+	return &search{db: db}
+}
+
+// 前方一致と部分一致に加え、ngram の FULLTEXT インデックスで一部の文字が一致するアカウントをあいまいに探す
+// 一致の仕方の順に並べ、あいまいな一致は関連度の順に後ろに置く
+func (r *search) Accounts(ctx context.Context, query string, offset, limit *uint64) ([]*object.Account, error) {
+	var entities []*object.Account
+
+	pattern := escapeLike(query)
+	q := "select * from account where username like ? or display_name like ? or match (username, display_name) against (?)" +
+		" order by username = ? desc, username like ? desc, display_name like ? desc, match (username, display_name) against (?) desc, followers_count desc, id"
+	args := []interface{}{pattern + "%", "%" + pattern + "%", query, query, pattern + "%", "%" + pattern + "%", query}
+	q, args = appendPage(q, args, offset, limit)
+	if err := r.db.SelectContext(ctx, &entities, q, args...); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+// 本文のHTMLではなく投稿時の原文を検索する
+// 未収載 (unlisted) の投稿は見つけられないようにするため、公開範囲が public の投稿だけを返す
+func (r *search) Statuses(ctx context.Context, viewerID *object.AccountID, query string, offset, limit *uint64) ([]*object.Status, error) {
+	var entities []*object.Status
+
+	q := "select * from status where match (text) against (?) and visibility = 'public' and reblog_of_id is null"
+	args := []interface{}{query}
+	if viewerID != nil {
+		q += " and account_id not in (" + hiddenAccountIDs + ")"
+		args = append(args, hiddenAccountArgs(*viewerID)...)
+	}
+	q += " order by match (text) against (?) desc, id desc"
+	args = append(args, query)
+	q, args = appendPage(q, args, offset, limit)
+	if err := r.db.SelectContext(ctx, &entities, q, args...); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (r *search) Tags(ctx context.Context, query string, offset, limit *uint64) ([]*object.Tag, error) {
+	var entities []*object.Tag
+
+	name := object.NormalizeTagName(query)
+	q := "select * from tag where name like ? order by name = ? desc, name"
+	args := []interface{}{escapeLike(name) + "%", name}
+	q, args = appendPage(q, args, offset, limit)
+	if err := r.db.SelectContext(ctx, &entities, q, args...); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+type (
+	searchSource struct {
+		db *sqlx.DB
+	}
+)
+
+// データベースの外に置く検索インデックスに読み込ませる
+func NewSearchSource(db *sqlx.DB) repository.SearchSource {
+	return &searchSource{db: db}
+}
+
+func (r *searchSource) AccountsAfter(ctx context.Context, afterID object.AccountID, limit uint64) ([]*object.Account, error) {
+	var entities []*object.Account
+	if err := r.db.SelectContext(ctx, &entities, "select * from account where id > ? order by id limit ?", afterID, limit); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+// 検索と同じく公開範囲が public の投稿だけを読み込み、ブーストは含まない
+func (r *searchSource) StatusesAfter(ctx context.Context, afterID uint64, limit uint64) ([]*object.Status, error) {
+	var entities []*object.Status
+	if err := r.db.SelectContext(ctx, &entities, "select * from status where id > ? and visibility = 'public' and reblog_of_id is null order by id limit ?", afterID, limit); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (r *searchSource) TagsAfter(ctx context.Context, afterID uint64, limit uint64) ([]*object.Tag, error) {
+	var entities []*object.Tag
+	if err := r.db.SelectContext(ctx, &entities, "select * from tag where id > ? order by id limit ?", afterID, limit); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+// タイムラインと同じく、ブロックしているか期限内でミュートしているアカウント
+func (r *searchSource) HiddenAccountIDs(ctx context.Context, viewerID object.AccountID) ([]object.AccountID, error) {
+	var ids []object.AccountID
+	if err := r.db.SelectContext(ctx, &ids, hiddenAccountIDs, hiddenAccountArgs(viewerID)...); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
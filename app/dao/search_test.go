@@ -0,0 +1,140 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yatter-backend-go/app/domain/object"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+
+	john := "John Smith"
+	for _, account := range []object.Account{
+		{Username: "johnny", PasswordHash: "password"},
+		{Username: "john", PasswordHash: "password"},
+		{Username: "smith", PasswordHash: "password", DisplayName: &john},
+		{Username: "jo_hn", PasswordHash: "password"},
+	} {
+		assert.NoError(t, accountRepo.Create(ctx, &account))
+	}
+	usernames := func(accounts []*object.Account) []string {
+		var usernames []string
+		for _, account := range accounts {
+			usernames = append(usernames, account.Username)
+		}
+		return usernames
+	}
+
+	// 完全一致、前方一致の順に並び、あいまいに一致するものも含む
+	accounts, err := searchRepo.Accounts(ctx, "john", nil, nil)
+	assert.NoError(t, err)
+	if assert.True(t, len(accounts) >= 3) {
+		assert.Equal(t, []string{"john", "johnny"}, usernames(accounts)[:2])
+		assert.Contains(t, usernames(accounts), "jo_hn")
+	}
+
+	// 綴りを誤っても見つかる
+	accounts, err = searchRepo.Accounts(ctx, "jonh", nil, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, usernames(accounts), "john")
+
+	// _ はワイルドカードとして扱わない
+	accounts, err = searchRepo.Accounts(ctx, "jo_", nil, nil)
+	assert.NoError(t, err)
+	if assert.NotEmpty(t, accounts) {
+		assert.Equal(t, "jo_hn", accounts[0].Username)
+	}
+
+	limit, offset := uint64(1), uint64(1)
+	accounts, err = searchRepo.Accounts(ctx, "john", &offset, &limit)
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 1)
+
+	for _, s := range []struct {
+		text       string
+		visibility string
+	}{
+		{"今日は良い天気です #yatter", object.VisibilityPublic},
+		{"天気が悪い", object.VisibilityUnlisted},
+		{"秘密の天気", object.VisibilityPrivate},
+		{"関係ない投稿", object.VisibilityPublic},
+	} {
+		status := &object.Status{AccountId: 1, Visibility: s.visibility}
+		status.SetText(s.text, nil)
+		assert.NoError(t, statusRepo.Create(ctx, status))
+	}
+
+	// 未収載の投稿は検索されない
+	statuses, err := searchRepo.Statuses(ctx, nil, "天気", nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 1)
+
+	// ブロック・ミュートしているアカウントの投稿は検索されない
+	viewerID := object.AccountID(2)
+	statuses, err = searchRepo.Statuses(ctx, &viewerID, "天気", nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 1)
+	assert.NoError(t, blockRepo.Create(ctx, 2, 1))
+	statuses, err = searchRepo.Statuses(ctx, &viewerID, "天気", nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 0)
+	assert.NoError(t, blockRepo.Delete(ctx, 2, 1))
+	assert.NoError(t, muteRepo.Create(ctx, object.NewMute(2, 1, false, 0, time.Now())))
+	statuses, err = searchRepo.Statuses(ctx, &viewerID, "天気", nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 0)
+
+	tags, err := searchRepo.Tags(ctx, "#Yat", nil, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, tags, 1) {
+		assert.Equal(t, "yatter", tags[0].Name)
+	}
+}
+
+func TestSearchSource(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(3))
+
+	accounts, err := searchSourceRepo.AccountsAfter(ctx, 1, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, accounts, 2) {
+		assert.Equal(t, object.AccountID(2), accounts[0].ID)
+	}
+
+	// 公開範囲が public の投稿だけを読み込み、ブーストは含まない
+	var ids []uint64
+	for _, visibility := range []string{object.VisibilityPublic, object.VisibilityUnlisted, object.VisibilityPublic} {
+		status := &object.Status{AccountId: 1, Visibility: visibility}
+		status.SetText("#yatter", nil)
+		assert.NoError(t, statusRepo.Create(ctx, status))
+		ids = append(ids, status.ID)
+	}
+	assert.NoError(t, statusRepo.Create(ctx, &object.Status{AccountId: 2, ReblogOfID: &ids[0]}))
+	statuses, err := searchSourceRepo.StatusesAfter(ctx, 0, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, statuses, 2) {
+		assert.Equal(t, ids[0], statuses[0].ID)
+		assert.Equal(t, ids[2], statuses[1].ID)
+	}
+	statuses, err = searchSourceRepo.StatusesAfter(ctx, ids[0], 1)
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 1)
+
+	tags, err := searchSourceRepo.TagsAfter(ctx, 0, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, tags, 1) {
+		assert.Equal(t, "yatter", tags[0].Name)
+	}
+
+	assert.NoError(t, blockRepo.Create(ctx, 1, 2))
+	assert.NoError(t, muteRepo.Create(ctx, object.NewMute(1, 3, false, 0, time.Now())))
+	hidden, err := searchSourceRepo.HiddenAccountIDs(ctx, 1)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []object.AccountID{2, 3}, hidden)
+}
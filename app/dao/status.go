@@ -2,6 +2,11 @@ package dao
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 	"yatter-backend-go/app/domain/object"
 	"yatter-backend-go/app/domain/repository"
 
@@ -14,15 +19,103 @@ type (
 	}
 )
 
+// Length of `status.thread_path` column
+const maxThreadPathLength = 2048
+
 func NewStatus(db *sqlx.DB) repository.Status {
 	return &status{db: db}
 }
 
 func (r *status) Create(ctx context.Context, status *object.Status) error {
-	_, err := r.db.ExecContext(ctx, "insert into status (account_id, content) values (?, ?)", status.AccountId, status.Content)
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := createStatus(ctx, tx, status); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// 予約投稿の公開でも使えるよう、呼び出し元のトランザクション内で投稿を作成する
+func createStatus(ctx context.Context, tx *sqlx.Tx, status *object.Status) error {
+	if len(status.MediaAttachments) > 0 {
+		status.HasMedia = true
+	}
+	if status.Visibility == "" {
+		status.Visibility = object.VisibilityPublic
+	}
+	res, err := tx.ExecContext(ctx, "insert into status (account_id, content, text, spoiler_text, sensitive, visibility, has_media, in_reply_to_id, in_reply_to_account_id, reblog_of_id) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", status.AccountId, status.Content, status.Source, status.SpoilerText, status.Sensitive, status.Visibility, status.HasMedia, status.InReplyToID, status.InReplyToAccountID, status.ReblogOfID)
 	if err != nil {
+		// 同時にブーストされた場合は一意キーで弾かれる
+		if status.ReblogOfID != nil && isDuplicateEntry(err) {
+			return repository.ErrAlreadyReblogged
+		}
 		return err
 	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	status.ID = uint64(id)
+
+	// 返信先のパスに自身のIDを繋げてスレッド内の位置を記録する
+	var parentPath string
+	if status.InReplyToID != nil {
+		if err := tx.QueryRowxContext(ctx, "select thread_path from status where id = ?", *status.InReplyToID).Scan(&parentPath); err != nil {
+			return err
+		}
+	}
+	status.ThreadPath = parentPath + strconv.FormatUint(status.ID, 10) + "/"
+	if len(status.ThreadPath) > maxThreadPathLength {
+		return fmt.Errorf("thread is too deep")
+	}
+	if _, err := tx.ExecContext(ctx, "update status set thread_path = ? where id = ?", status.ThreadPath, status.ID); err != nil {
+		return err
+	}
+
+	if status.ReblogOfID != nil {
+		if _, err := tx.ExecContext(ctx, "update status set reblogs_count = reblogs_count + 1 where id = ?", *status.ReblogOfID); err != nil {
+			return err
+		}
+		if err := notifyStatusAuthor(ctx, tx, status.AccountId, object.NotificationReblog, *status.ReblogOfID); err != nil {
+			return err
+		}
+	}
+
+	if len(status.MediaAttachments) > 0 {
+		ids := make([]uint64, len(status.MediaAttachments))
+		for i, attachment := range status.MediaAttachments {
+			ids[i] = attachment.ID
+		}
+		// 他人のメディアや投稿済み・予約済みのメディアは紐付けない
+		query, args, err := sqlx.In("update attachment set status_id = ? where id in (?) and account_id = ? and status_id is null and scheduled_status_id is null", status.ID, ids, status.AccountId)
+		if err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, tx.Rebind(query), args...)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n != int64(len(ids)) {
+			return fmt.Errorf("media attachments are not available")
+		}
+		for _, attachment := range status.MediaAttachments {
+			attachment.StatusID = &status.ID
+		}
+	}
+
+	if err := createMentions(ctx, tx, status); err != nil {
+		return err
+	}
+	if err := createTags(ctx, tx, status); err != nil {
+		return err
+	}
+
+	if status.Poll != nil {
+		return createPoll(ctx, tx, status)
+	}
 	return nil
 }
 
@@ -36,33 +129,300 @@ func (r *status) Retrieve(ctx context.Context, id uint64) (*object.Status, error
 	return entity, nil
 }
 
+func (r *status) Update(ctx context.Context, status *object.Status) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	var id uint64
+	if err := tx.QueryRowContext(ctx, "select id from status where id = ? for update", status.ID).Scan(&id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	// 編集前の内容を、その内容になった時刻とともに履歴に残す
+	if _, err := tx.ExecContext(ctx, "insert into status_edit (status_id, content, create_at) select id, content, coalesce(edited_at, create_at) from status where id = ?", status.ID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "update status set content = ?, text = ?, edited_at = now() where id = ?", status.Content, status.Source, status.ID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	// メンションとタグは編集後の本文から作り直す
+	if _, err := tx.ExecContext(ctx, "delete from mention where status_id = ?", status.ID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "delete from status_tag where status_id = ?", status.ID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := createMentions(ctx, tx, status); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := createTags(ctx, tx, status); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *status) RetrieveEdits(ctx context.Context, statusID uint64) ([]*object.StatusEdit, error) {
+	var entities []*object.StatusEdit
+	err := r.db.SelectContext(ctx, &entities, "select * from status_edit where status_id = ? order by id", statusID)
+	if err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (r *status) RetrieveByIDs(ctx context.Context, ids []uint64) ([]*object.Status, error) {
+	var entities []*object.Status
+	if len(ids) == 0 {
+		return entities, nil
+	}
+
+	query, args, err := sqlx.In("select * from status where id in (?)", ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &entities, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (r *status) RetrieveReblog(ctx context.Context, accountID object.AccountID, statusID uint64) (*object.Status, error) {
+	entity := new(object.Status)
+	err := r.db.QueryRowxContext(ctx, "select * from status where account_id = ? and reblog_of_id = ?", accountID, statusID).StructScan(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+// ブーストしていない場合は何もしない
+func (r *status) DeleteReblog(ctx context.Context, accountID object.AccountID, statusID uint64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	res, err := tx.ExecContext(ctx, "delete from status where account_id = ? and reblog_of_id = ?", accountID, statusID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		tx.Rollback()
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx, "update status set reblogs_count = reblogs_count - 1 where id = ?", statusID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *status) RebloggedStatusIDs(ctx context.Context, accountID object.AccountID, statusIDs []uint64) ([]uint64, error) {
+	var ids []uint64
+	if len(statusIDs) == 0 {
+		return ids, nil
+	}
+
+	query, args, err := sqlx.In("select reblog_of_id from status where account_id = ? and reblog_of_id in (?)", accountID, statusIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &ids, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// 投稿を参照しているお気に入り・ブースト・返信・メディアも合わせて片付ける
+// 存在しない場合は何もしない
 func (r *status) Delete(ctx context.Context, id uint64) error {
-	_, err := r.db.ExecContext(ctx, "delete from status where id = ?", id)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
-	return nil
+	var reblogOfID *uint64
+	if err := tx.QueryRowContext(ctx, "select reblog_of_id from status where id = ? for update", id).Scan(&reblogOfID); err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+	if reblogOfID != nil {
+		if _, err := tx.ExecContext(ctx, "update status set reblogs_count = reblogs_count - 1 where id = ?", *reblogOfID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "delete from favourite where status_id = ?", id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "delete from status_edit where status_id = ?", id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	// 選択肢と投票は外部キーで一緒に削除される
+	if _, err := tx.ExecContext(ctx, "delete from poll where status_id = ?", id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "delete from status where reblog_of_id = ?", id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "update status set in_reply_to_id = null where in_reply_to_id = ?", id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	// メディアは削除せず、書き直しの投稿に添付し直せるようにする
+	if _, err := tx.ExecContext(ctx, "update attachment set status_id = null where status_id = ?", id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	// メンション・タグ・通知は外部キーで一緒に削除される
+	if _, err := tx.ExecContext(ctx, "delete from status where id = ?", id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *status) Context(ctx context.Context, status *object.Status) ([]*object.Status, []*object.Status, error) {
+	var ancestors []*object.Status
+	var ancestorIDs []uint64
+	for _, s := range strings.Split(strings.TrimSuffix(status.ThreadPath, "/"), "/") {
+		id, err := strconv.ParseUint(s, 10, 64)
+		if err != nil || id == status.ID {
+			continue
+		}
+		ancestorIDs = append(ancestorIDs, id)
+	}
+	if len(ancestorIDs) > 0 {
+		query, args, err := sqlx.In("select * from status where id in (?) order by id", ancestorIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := r.db.SelectContext(ctx, &ancestors, r.db.Rebind(query), args...); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var descendants []*object.Status
+	if status.ThreadPath == "" {
+		return ancestors, descendants, nil
+	}
+	if err := r.db.SelectContext(ctx, &descendants, "select * from status where thread_path like ? and id <> ? order by id", status.ThreadPath+"%", status.ID); err != nil {
+		return nil, nil, err
+	}
+
+	return ancestors, descendants, nil
 }
 
-func (r *status) PublicTimeline(ctx context.Context, only_media, max_id, since_id, limit *uint64) ([]*object.Status, error) {
+// 閲覧者がブロックしているか、期限内でミュートしているアカウント
+const hiddenAccountIDs = "select target_account_id from block where account_id = ?" +
+	" union select target_account_id from mute where account_id = ? and " + activeMute
+
+// 閲覧者がブロック・ミュートしているアカウントの投稿のID。他人によるブーストを除くのに使う
+// 公開・タグタイムラインはブーストを流さないため、ブーストを含むタイムラインだけが使う
+const hiddenReblogOfIDs = "select original.id from status as original where original.account_id in (" + hiddenAccountIDs + ")"
+
+// hiddenAccountIDs のプレースホルダに渡す値
+func hiddenAccountArgs(viewerID object.AccountID) []interface{} {
+	return []interface{}{viewerID, viewerID, time.Now()}
+}
+
+func (r *status) PublicTimeline(ctx context.Context, viewerID *object.AccountID, only_media, max_id, since_id, limit *uint64) ([]*object.Status, error) {
 	var entities []*object.Status
 
-	query, args := buildQuery("status", "id", since_id, max_id, limit)
-	err := r.db.SelectContext(ctx, &entities, query, args...)
+	// 公開範囲が public の投稿だけを流し、ブーストは流さない
+	conditions := []string{"visibility = 'public'", "reblog_of_id is null"}
+	var args []interface{}
+	if viewerID != nil {
+		conditions = append(conditions, "account_id not in ("+hiddenAccountIDs+")")
+		args = append(args, hiddenAccountArgs(*viewerID)...)
+	}
+	if isTrue(only_media) {
+		conditions = append(conditions, "has_media = 1")
+	}
+	query, pageArgs := buildQuery("status", "id", conditions, since_id, max_id, limit)
+	err := r.db.SelectContext(ctx, &entities, query, append(args, pageArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+// タグで絞り込んだ public の投稿を返す。ブーストは流さない
+// タグ名から status_tag の (tag_id, status_id) インデックスで投稿IDを引けるよう、条件はサブクエリで書く
+func (r *status) TagTimeline(ctx context.Context, viewerID *object.AccountID, filter object.TagFilter, only_media, max_id, since_id, limit *uint64) ([]*object.Status, error) {
+	var entities []*object.Status
+	if len(filter.Any) == 0 && len(filter.All) == 0 {
+		return entities, nil
+	}
+
+	const taggedStatusIDs = "select status_tag.status_id from status_tag join tag on tag.id = status_tag.tag_id where tag.name in (?)"
+	conditions := []string{"visibility = 'public'", "reblog_of_id is null"}
+	var args []interface{}
+	if len(filter.Any) > 0 {
+		conditions = append(conditions, "id in ("+taggedStatusIDs+")")
+		args = append(args, filter.Any)
+	}
+	for _, name := range filter.All {
+		conditions = append(conditions, "id in ("+taggedStatusIDs+")")
+		args = append(args, []string{name})
+	}
+	if len(filter.None) > 0 {
+		conditions = append(conditions, "id not in ("+taggedStatusIDs+")")
+		args = append(args, filter.None)
+	}
+	if viewerID != nil {
+		conditions = append(conditions, "account_id not in ("+hiddenAccountIDs+")")
+		args = append(args, hiddenAccountArgs(*viewerID)...)
+	}
+	if isTrue(only_media) {
+		conditions = append(conditions, "has_media = 1")
+	}
+	query, pageArgs := buildQuery("status", "id", conditions, since_id, max_id, limit)
+
+	query, args, err := sqlx.In(query, append(args, pageArgs...)...)
 	if err != nil {
 		return nil, err
 	}
+	if err := r.db.SelectContext(ctx, &entities, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
 	return entities, nil
 }
 
-func (r *status) HomeTimeline(ctx context.Context, accountID object.AccountID, only_media, max_id, since_id, limit *uint64) ([]object.Status, error) {
-	var entities []object.Status
+// フォローしているアカウントの投稿とブーストを返す
+// ブーストはブーストしたアカウントの投稿として保存されているため、元の投稿者をフォローしていなくても含まれる
+// フォロワー限定の投稿は含み、ダイレクトは自分への返信か自分をメンションしたものだけを含む
+// ブロック・ミュートしているアカウントの投稿は、他人によるブーストも含まない
+func (r *status) HomeTimeline(ctx context.Context, accountID object.AccountID, only_media, max_id, since_id, limit *uint64) ([]*object.Status, error) {
+	var entities []*object.Status
 
 	query := `select status.* from status join relationship on status.account_id = relationship.follower_id where relationship.following_id = ?`
+	query += " and (status.visibility <> 'direct' or status.in_reply_to_account_id = ? or exists (select 1 from mention where mention.status_id = status.id and mention.account_id = ?))"
 
-	args := []interface{}{accountID}
+	query += " and status.account_id not in (" + hiddenAccountIDs + ")"
+	query += " and (status.reblog_of_id is null or status.reblog_of_id not in (" + hiddenReblogOfIDs + "))"
 
-	// TODO only_media
+	args := []interface{}{accountID, accountID, accountID}
+	args = append(args, hiddenAccountArgs(accountID)...)
+	args = append(args, hiddenAccountArgs(accountID)...)
+
+	if isTrue(only_media) {
+		query += " and status.has_media = 1"
+	}
 
 	if max_id != nil {
 		query += " and status.id <= ?"
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
 )
 
 func TestStatusCreate(t *testing.T) {
@@ -21,6 +22,12 @@ func TestStatusCreate(t *testing.T) {
 				AccountId: 1, Content: "Test Content",
 			}},
 		},
+		{
+			name: "WithContentWarning",
+			statuses: []object.Status{{
+				AccountId: 1, Content: "Test Content", SpoilerText: "CW", Sensitive: true,
+			}},
+		},
 	}
 
 	for _, tt := range tests {
@@ -30,6 +37,11 @@ func TestStatusCreate(t *testing.T) {
 			for _, status := range tt.statuses {
 				err := statusRepo.Create(ctx, &status)
 				assert.NoError(t, err)
+
+				got, err := statusRepo.Retrieve(ctx, status.ID)
+				assert.NoError(t, err)
+				assert.Equal(t, status.SpoilerText, got.SpoilerText)
+				assert.Equal(t, status.Sensitive, got.Sensitive)
 			}
 		})
 	}
@@ -121,6 +133,238 @@ func TestStatusDelete(t *testing.T) {
 	}
 }
 
+func TestStatusDeleteCascade(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(2))
+
+	attachment := &object.Attachment{AccountID: 1, Type: object.AttachmentTypeImage, URL: "/media/a.png"}
+	assert.NoError(t, attachmentRepo.Create(ctx, attachment))
+	status := &object.Status{AccountId: 1, Content: "Test Content", MediaAttachments: []*object.Attachment{attachment}}
+	assert.NoError(t, statusRepo.Create(ctx, status))
+
+	reply := &object.Status{AccountId: 2, Content: "reply", InReplyToID: &status.ID, InReplyToAccountID: &status.AccountId}
+	assert.NoError(t, statusRepo.Create(ctx, reply))
+	reblog := &object.Status{AccountId: 2, ReblogOfID: &status.ID}
+	assert.NoError(t, statusRepo.Create(ctx, reblog))
+	assert.NoError(t, favouriteRepo.Create(ctx, 2, status.ID))
+
+	assert.NoError(t, statusRepo.Delete(ctx, status.ID))
+
+	_, err := statusRepo.Retrieve(ctx, status.ID)
+	assert.Error(t, err)
+	_, err = statusRepo.Retrieve(ctx, reblog.ID)
+	assert.Error(t, err)
+
+	got, err := statusRepo.Retrieve(ctx, reply.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, got.InReplyToID)
+	assert.Equal(t, status.AccountId, *got.InReplyToAccountID)
+
+	ids, err := favouriteRepo.FavouritedStatusIDs(ctx, 2, []uint64{status.ID})
+	assert.NoError(t, err)
+	assert.Empty(t, ids)
+
+	// 書き直しの投稿に添付し直せる
+	detached, err := attachmentRepo.Retrieve(ctx, attachment.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, detached.StatusID)
+	assert.NoError(t, statusRepo.Create(ctx, &object.Status{AccountId: 1, Content: "redraft", MediaAttachments: []*object.Attachment{detached}}))
+}
+
+func TestStatusDeleteReblog(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(2))
+
+	status := &object.Status{AccountId: 1, Content: "Test Content"}
+	assert.NoError(t, statusRepo.Create(ctx, status))
+	reblog := &object.Status{AccountId: 2, ReblogOfID: &status.ID}
+	assert.NoError(t, statusRepo.Create(ctx, reblog))
+
+	assert.NoError(t, statusRepo.Delete(ctx, reblog.ID))
+
+	got, err := statusRepo.Retrieve(ctx, status.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), got.ReblogsCount)
+}
+
+func TestStatusUpdate(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+
+	status := &object.Status{AccountId: 1, Content: "first"}
+	assert.NoError(t, statusRepo.Create(ctx, status))
+
+	status.Content = "second"
+	assert.NoError(t, statusRepo.Update(ctx, status))
+	status.Content = "third"
+	assert.NoError(t, statusRepo.Update(ctx, status))
+
+	got, err := statusRepo.Retrieve(ctx, status.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "third", got.Content)
+	assert.NotNil(t, got.EditedAt)
+
+	edits, err := statusRepo.RetrieveEdits(ctx, status.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, edits, 2) {
+		assert.Equal(t, "first", edits[0].Content)
+		assert.Equal(t, "second", edits[1].Content)
+	}
+
+	// 編集履歴ごと削除できる
+	assert.NoError(t, statusRepo.Delete(ctx, status.ID))
+}
+
+func TestStatusContext(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(1))
+
+	// 1 ─┬─ 2 ─── 4
+	//    └─ 3
+	// 5
+	parents := []uint64{0, 0, 1, 1, 2, 0}
+	for i := 1; i <= 5; i++ {
+		status := &object.Status{AccountId: 1, Content: "Test Content " + strings.Repeat("#", i)}
+		if parents[i] != 0 {
+			status.InReplyToID = newUint64(parents[i])
+		}
+		assert.NoError(t, statusRepo.Create(ctx, status))
+	}
+
+	tests := []struct {
+		name            string
+		id              uint64
+		wantPath        string
+		wantAncestors   []uint64
+		wantDescendants []uint64
+	}{
+		{
+			name:            "Root",
+			id:              1,
+			wantPath:        "1/",
+			wantAncestors:   []uint64{},
+			wantDescendants: []uint64{2, 3, 4},
+		},
+		{
+			name:            "Middle",
+			id:              2,
+			wantPath:        "1/2/",
+			wantAncestors:   []uint64{1},
+			wantDescendants: []uint64{4},
+		},
+		{
+			name:            "Leaf",
+			id:              4,
+			wantPath:        "1/2/4/",
+			wantAncestors:   []uint64{1, 2},
+			wantDescendants: []uint64{},
+		},
+		{
+			name:            "Standalone",
+			id:              5,
+			wantPath:        "5/",
+			wantAncestors:   []uint64{},
+			wantDescendants: []uint64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := statusRepo.Retrieve(ctx, tt.id)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPath, status.ThreadPath)
+
+			ancestors, descendants, err := statusRepo.Context(ctx, status)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantAncestors, statusIDs(ancestors))
+			assert.Equal(t, tt.wantDescendants, statusIDs(descendants))
+		})
+	}
+}
+
+func TestReblog(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	defer cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(3))
+	// 1 は 2 をフォローしているが 3 はフォローしていない
+	insertRelationshipDB(t, ctx, []object.Relationship{{FollowingId: 1, FollowerId: 2}})
+
+	original := &object.Status{AccountId: 3, Content: "Test Content"}
+	assert.NoError(t, statusRepo.Create(ctx, original))
+	reblog := &object.Status{AccountId: 2, ReblogOfID: &original.ID}
+	assert.NoError(t, statusRepo.Create(ctx, reblog))
+
+	got, err := statusRepo.Retrieve(ctx, original.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), got.ReblogsCount)
+
+	got, err = statusRepo.RetrieveReblog(ctx, 2, original.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, reblog.ID, got.ID)
+
+	// 同じ投稿を2回ブーストすることはできない
+	assert.Equal(t, repository.ErrAlreadyReblogged, statusRepo.Create(ctx, &object.Status{AccountId: 2, ReblogOfID: &original.ID}))
+
+	home, err := statusRepo.HomeTimeline(ctx, 1, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{reblog.ID}, statusIDs(home))
+
+	public, err := statusRepo.PublicTimeline(ctx, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{original.ID}, statusIDs(public))
+
+	ids, err := statusRepo.RebloggedStatusIDs(ctx, 2, []uint64{original.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{original.ID}, ids)
+
+	assert.NoError(t, statusRepo.DeleteReblog(ctx, 2, original.ID))
+	assert.NoError(t, statusRepo.DeleteReblog(ctx, 2, original.ID))
+
+	got, err = statusRepo.Retrieve(ctx, original.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), got.ReblogsCount)
+	_, err = statusRepo.Retrieve(ctx, reblog.ID)
+	assert.Error(t, err)
+}
+
+func TestVisibility(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	defer cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(3))
+	// 1 は 2 をフォローしている
+	insertRelationshipDB(t, ctx, []object.Relationship{{FollowingId: 1, FollowerId: 2}})
+
+	mine := &object.Status{AccountId: 1, Content: "Test Content"}
+	assert.NoError(t, statusRepo.Create(ctx, mine))
+	others := &object.Status{AccountId: 3, Content: "Test Content"}
+	assert.NoError(t, statusRepo.Create(ctx, others))
+
+	var ids []uint64
+	for _, status := range []*object.Status{
+		{AccountId: 2, Content: "public", Visibility: object.VisibilityPublic},
+		{AccountId: 2, Content: "unlisted", Visibility: object.VisibilityUnlisted},
+		{AccountId: 2, Content: "private", Visibility: object.VisibilityPrivate},
+		{AccountId: 2, Content: "direct to 1", Visibility: object.VisibilityDirect, InReplyToID: &mine.ID, InReplyToAccountID: &mine.AccountId},
+		{AccountId: 2, Content: "direct to 3", Visibility: object.VisibilityDirect, InReplyToID: &others.ID, InReplyToAccountID: &others.AccountId},
+	} {
+		assert.NoError(t, statusRepo.Create(ctx, status))
+		ids = append(ids, status.ID)
+	}
+
+	public, err := statusRepo.PublicTimeline(ctx, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []uint64{mine.ID, others.ID, ids[0]}, statusIDs(public))
+
+	home, err := statusRepo.HomeTimeline(ctx, 1, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, ids[:4], statusIDs(home))
+}
+
 func TestPublicTimeline(t *testing.T) {
 	ctx := context.Background()
 	cleanupDB()
@@ -179,7 +423,7 @@ func TestPublicTimeline(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			only_media, max_id, since_id, limit := tt.wantArgs()
-			allStatuses, err := statusRepo.PublicTimeline(ctx, only_media, max_id, since_id, limit)
+			allStatuses, err := statusRepo.PublicTimeline(ctx, nil, only_media, max_id, since_id, limit)
 			assert.NoError(t, err)
 			assert.Equal(t, tt.expectLen, len(allStatuses))
 		})
@@ -264,6 +508,128 @@ func TestHomeTimeline(t *testing.T) {
 	}
 }
 
+func TestOnlyMedia(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	defer cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(3))
+	// 1 は 2 と 3 をフォローし、メディア付きの投稿をするのは 2 だけ
+	insertRelationshipDB(t, ctx, []object.Relationship{
+		{FollowingId: 1, FollowerId: 2},
+		{FollowingId: 1, FollowerId: 3},
+		{FollowingId: 2, FollowerId: 1},
+		{FollowingId: 3, FollowerId: 1},
+	})
+	for i := 1; i <= 4; i++ {
+		status := &object.Status{AccountId: 2, Content: "Test Content " + strings.Repeat("#", i)}
+		if i%2 == 0 {
+			attachment := &object.Attachment{AccountID: 2, Type: object.AttachmentTypeImage, URL: "/media/" + strings.Repeat("a", i)}
+			assert.NoError(t, attachmentRepo.Create(ctx, attachment))
+			status.MediaAttachments = []*object.Attachment{attachment}
+		}
+		assert.NoError(t, statusRepo.Create(ctx, status))
+	}
+	assert.NoError(t, statusRepo.Create(ctx, &object.Status{AccountId: 3, Content: "Test Content"}))
+
+	tests := []struct {
+		name      string
+		onlyMedia *uint64
+		expectLen int
+	}{
+		{
+			name:      "All",
+			onlyMedia: nil,
+			expectLen: 5,
+		},
+		{
+			name:      "OnlyMediaFalse",
+			onlyMedia: newUint64(0),
+			expectLen: 5,
+		},
+		{
+			name:      "OnlyMedia",
+			onlyMedia: newUint64(1),
+			expectLen: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publicStatuses, err := statusRepo.PublicTimeline(ctx, nil, tt.onlyMedia, nil, nil, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectLen, len(publicStatuses))
+
+			homeStatuses, err := statusRepo.HomeTimeline(ctx, 1, tt.onlyMedia, nil, nil, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectLen, len(homeStatuses))
+
+			if tt.onlyMedia != nil && *tt.onlyMedia != 0 {
+				for _, status := range publicStatuses {
+					assert.True(t, status.HasMedia)
+				}
+			}
+		})
+	}
+
+	followers, err := relationshipRepo.RetrieveFollowers(ctx, 1, newUint64(1), nil, nil, nil)
+	assert.NoError(t, err)
+	if assert.Equal(t, 1, len(followers)) {
+		assert.Equal(t, uint64(2), followers[0].ID)
+	}
+
+	followers, err = relationshipRepo.RetrieveFollowers(ctx, 1, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(followers))
+}
+
 func newUint64(i uint64) *uint64 {
 	return &i
 }
+
+func statusIDs(statuses []*object.Status) []uint64 {
+	ids := []uint64{}
+	for _, status := range statuses {
+		ids = append(ids, status.ID)
+	}
+	return ids
+}
+
+func TestTagTimeline(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(1))
+
+	for _, text := range []string{"#go", "#go #yatter", "#golang #spam", "#yatter"} {
+		status := &object.Status{AccountId: 1}
+		status.SetText(text, nil)
+		assert.NoError(t, statusRepo.Create(ctx, status))
+	}
+	private := &object.Status{AccountId: 1, Visibility: object.VisibilityPrivate}
+	private.SetText("#go", nil)
+	assert.NoError(t, statusRepo.Create(ctx, private))
+
+	tests := []struct {
+		name    string
+		filter  object.TagFilter
+		wantIDs []uint64
+	}{
+		{name: "tag", filter: object.TagFilter{Any: []string{"go"}}, wantIDs: []uint64{2, 1}},
+		{name: "any", filter: object.TagFilter{Any: []string{"go", "golang"}}, wantIDs: []uint64{3, 2, 1}},
+		{name: "all", filter: object.TagFilter{Any: []string{"go"}, All: []string{"yatter"}}, wantIDs: []uint64{2}},
+		{name: "none", filter: object.TagFilter{Any: []string{"go", "golang"}, None: []string{"spam", "yatter"}}, wantIDs: []uint64{1}},
+		{name: "unknown", filter: object.TagFilter{Any: []string{"unknown"}}, wantIDs: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statuses, err := statusRepo.TagTimeline(ctx, nil, tt.filter, nil, nil, nil, nil)
+			assert.NoError(t, err)
+			var ids []uint64
+			for _, status := range statuses {
+				ids = append(ids, status.ID)
+			}
+			// 同じ秒に作成されるため順序は比べない
+			assert.ElementsMatch(t, tt.wantIDs, ids)
+		})
+	}
+}
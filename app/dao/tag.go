@@ -0,0 +1,57 @@
+package dao
+
+import (
+	"context"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	tag struct {
+		db *sqlx.DB
+	}
+)
+
+func NewTag(db *sqlx.DB) repository.Tag {
+	return &tag{db: db}
+}
+
+// 投稿の作成・編集と同じトランザクション内でタグを記録する
+// 初めて使われたタグはここで作成する
+func createTags(ctx context.Context, tx *sqlx.Tx, status *object.Status) error {
+	for _, t := range status.Tags {
+		// 既存のタグでも last_insert_id() でそのIDが返るようにする
+		res, err := tx.ExecContext(ctx, "insert into tag (name) values (?) on duplicate key update id = last_insert_id(id)", t.Name)
+		if err != nil {
+			return err
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		t.ID = uint64(id)
+		if _, err := tx.ExecContext(ctx, "insert into status_tag (status_id, tag_id) values (?, ?)", status.ID, t.ID); err != nil {
+			return err
+		}
+		t.StatusID = status.ID
+	}
+	return nil
+}
+
+func (r *tag) RetrieveByStatusIDs(ctx context.Context, statusIDs []uint64) ([]*object.Tag, error) {
+	var entities []*object.Tag
+	if len(statusIDs) == 0 {
+		return entities, nil
+	}
+
+	query, args, err := sqlx.In("select tag.*, status_tag.status_id from tag join status_tag on status_tag.tag_id = tag.id where status_tag.status_id in (?) order by status_tag.status_id, tag.name", statusIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &entities, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
@@ -0,0 +1,51 @@
+package dao
+
+import (
+	"context"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	token struct {
+		db *sqlx.DB
+	}
+)
+
+func NewToken(db *sqlx.DB) repository.Token {
+	return &token{db: db}
+}
+
+func (r *token) Create(ctx context.Context, token *object.Token) error {
+	res, err := r.db.ExecContext(ctx, "insert into access_token (account_id, application_id, token, scopes, expires_at) values (?, ?, ?, ?, ?)", token.AccountID, token.ApplicationID, token.AccessToken, token.Scopes, token.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	token.ID = uint64(id)
+	return nil
+}
+
+func (r *token) Retrieve(ctx context.Context, accessToken string) (*object.Token, error) {
+	entity := new(object.Token)
+	err := r.db.QueryRowxContext(ctx, "select * from access_token where token = ?", accessToken).StructScan(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+func (r *token) Delete(ctx context.Context, accessToken string) error {
+	_, err := r.db.ExecContext(ctx, "delete from access_token where token = ?", accessToken)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yatter-backend-go/app/domain/object"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenCreateAndRetrieve(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(1))
+
+	token, err := object.NewToken(1, object.FullScopes, time.Hour)
+	assert.NoError(t, err)
+	assert.NoError(t, tokenRepo.Create(ctx, token))
+
+	got, err := tokenRepo.Retrieve(ctx, token.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, object.AccountID(1), got.AccountID)
+	assert.False(t, got.IsExpired(time.Now()))
+
+	_, err = tokenRepo.Retrieve(ctx, "unknown")
+	assert.Error(t, err)
+}
+
+func TestTokenDelete(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(1))
+
+	token, err := object.NewToken(1, object.FullScopes, time.Hour)
+	assert.NoError(t, err)
+	assert.NoError(t, tokenRepo.Create(ctx, token))
+
+	assert.NoError(t, tokenRepo.Delete(ctx, token.AccessToken))
+	_, err = tokenRepo.Retrieve(ctx, token.AccessToken)
+	assert.Error(t, err)
+}
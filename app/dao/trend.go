@@ -0,0 +1,126 @@
+package dao
+
+import (
+	"context"
+	"time"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	trend struct {
+		db *sqlx.DB
+	}
+)
+
+func NewTrend(db *sqlx.DB) repository.Trend {
+	return &trend{db: db}
+}
+
+// 集計時刻から何日前の窓に入るかでまとめる。暦日ではなく集計時刻からの24時間ごとに区切る
+func (r *trend) TagActivity(ctx context.Context, now time.Time, windows int) ([]*object.TagActivity, error) {
+	var entities []*object.TagActivity
+	window := int64(object.TrendWindow.Seconds())
+	since := now.Add(-time.Duration(windows) * object.TrendWindow)
+
+	query := `select tag.id as tag_id, tag.name, floor(timestampdiff(second, status.create_at, ?) / ?) as days_ago, count(*) as uses, count(distinct status.account_id) as accounts
+from status_tag join status on status.id = status_tag.status_id join tag on tag.id = status_tag.tag_id
+where status.create_at > ? and status.create_at <= ? and status.visibility = 'public' and status.reblog_of_id is null
+group by tag.id, tag.name, days_ago`
+	if err := r.db.SelectContext(ctx, &entities, query, now, window, since, now); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+// お気に入りとブーストを合わせて数える。投稿者自身によるものは数えない
+func (r *trend) StatusActivity(ctx context.Context, since, postedSince time.Time) ([]*object.StatusActivity, error) {
+	var entities []*object.StatusActivity
+
+	query := `select status.id as status_id, status.create_at, count(*) as interactions
+from status join (
+  select status_id, account_id from favourite where create_at > ?
+  union all
+  select reblog_of_id as status_id, account_id from status where reblog_of_id is not null and create_at > ?
+) as interaction on interaction.status_id = status.id and interaction.account_id <> status.account_id
+where status.create_at > ? and status.visibility = 'public' and status.reblog_of_id is null
+group by status.id, status.create_at`
+	if err := r.db.SelectContext(ctx, &entities, query, since, since, postedSince); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (r *trend) ReplaceTags(ctx context.Context, trends []*object.TagTrend) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "delete from trend_tag"); err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, t := range trends {
+		if _, err := tx.ExecContext(ctx, "insert into trend_tag (tag_id, score, history) values (?, ?, ?)", t.TagID, t.Score, t.History); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *trend) ReplaceStatuses(ctx context.Context, trends []*object.StatusTrend) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "delete from trend_status"); err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, t := range trends {
+		if _, err := tx.ExecContext(ctx, "insert into trend_status (status_id, score) values (?, ?)", t.StatusID, t.Score); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *trend) Tags(ctx context.Context, limit *uint64) ([]*object.Tag, error) {
+	var entities []*object.Tag
+
+	query := "select tag.*, trend_tag.history from trend_tag join tag on tag.id = trend_tag.tag_id order by trend_tag.score desc, tag.id"
+	var args []interface{}
+	if limit != nil {
+		query += " limit ?"
+		args = append(args, *limit)
+	}
+	if err := r.db.SelectContext(ctx, &entities, query, args...); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+// 閲覧者がブロック・ミュートしているアカウントの投稿は除く
+func (r *trend) Statuses(ctx context.Context, viewerID *object.AccountID, limit *uint64) ([]*object.Status, error) {
+	var entities []*object.Status
+
+	query := "select status.* from trend_status join status on status.id = trend_status.status_id"
+	var args []interface{}
+	if viewerID != nil {
+		query += " where status.account_id not in (" + hiddenAccountIDs + ")"
+		args = append(args, hiddenAccountArgs(*viewerID)...)
+	}
+	query += " order by trend_status.score desc, status.id desc"
+	if limit != nil {
+		query += " limit ?"
+		args = append(args, *limit)
+	}
+	if err := r.db.SelectContext(ctx, &entities, query, args...); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
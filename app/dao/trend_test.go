@@ -0,0 +1,89 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yatter-backend-go/app/domain/object"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrend(t *testing.T) {
+	ctx := context.Background()
+	cleanupDB()
+	insertAccountDB(t, ctx, createAccountObject(2))
+
+	for _, s := range []struct {
+		accountID  object.AccountID
+		text       string
+		visibility string
+	}{
+		{1, "#go", object.VisibilityPublic},
+		{2, "#go #yatter", object.VisibilityPublic},
+		{2, "#secret", object.VisibilityPrivate},
+	} {
+		status := &object.Status{AccountId: s.accountID, Visibility: s.visibility}
+		status.SetText(s.text, nil)
+		assert.NoError(t, statusRepo.Create(ctx, status))
+	}
+	assert.NoError(t, favouriteRepo.Create(ctx, 2, 1))
+	// 投稿者自身のお気に入りは数えない
+	assert.NoError(t, favouriteRepo.Create(ctx, 2, 2))
+
+	// 作成直後の投稿も最新の窓に入るよう、少し先の時刻で集計する
+	now := time.Now().Add(time.Minute)
+
+	activities, err := trendRepo.TagActivity(ctx, now, object.TrendHistoryDays)
+	assert.NoError(t, err)
+	uses := make(map[string]uint64)
+	for _, activity := range activities {
+		assert.Equal(t, 0, activity.DaysAgo)
+		uses[activity.Name] = activity.Accounts
+	}
+	assert.Equal(t, map[string]uint64{"go": 2, "yatter": 1}, uses)
+
+	tags := object.TagTrends(activities, now)
+	assert.NoError(t, trendRepo.ReplaceTags(ctx, tags))
+	trending, err := trendRepo.Tags(ctx, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, trending, 2) {
+		assert.Equal(t, "go", trending[0].Name)
+		assert.Len(t, trending[0].History, object.TrendHistoryDays)
+	}
+
+	interactions, err := trendRepo.StatusActivity(ctx, now.Add(-object.TrendWindow), now.Add(-object.MaxTrendingStatusAge))
+	assert.NoError(t, err)
+	if assert.Len(t, interactions, 1) {
+		assert.Equal(t, uint64(1), interactions[0].StatusID)
+		assert.Equal(t, uint64(1), interactions[0].Interactions)
+	}
+
+	assert.NoError(t, trendRepo.ReplaceStatuses(ctx, object.StatusTrends(interactions, now)))
+	statuses, err := trendRepo.Statuses(ctx, nil, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, statuses, 1) {
+		assert.Equal(t, uint64(1), statuses[0].ID)
+	}
+
+	// ブロック・ミュートしているアカウントの投稿は返さない
+	viewerID := object.AccountID(2)
+	statuses, err = trendRepo.Statuses(ctx, &viewerID, nil)
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 1)
+	assert.NoError(t, blockRepo.Create(ctx, 2, 1))
+	statuses, err = trendRepo.Statuses(ctx, &viewerID, nil)
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 0)
+	assert.NoError(t, blockRepo.Delete(ctx, 2, 1))
+	assert.NoError(t, muteRepo.Create(ctx, object.NewMute(2, 1, false, 0, time.Now())))
+	statuses, err = trendRepo.Statuses(ctx, &viewerID, nil)
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 0)
+
+	// 集計し直すと前回の結果は置き換えられる
+	assert.NoError(t, trendRepo.ReplaceStatuses(ctx, nil))
+	statuses, err = trendRepo.Statuses(ctx, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 0)
+}
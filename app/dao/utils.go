@@ -2,14 +2,18 @@ package dao
 
 import (
 	"strings"
+
+	"github.com/go-sql-driver/mysql"
 )
 
-func buildQuery(DBName, idColumnName string, since_id, max_id, limit *uint64) (string, []interface{}) {
+// MySQL error number of a duplicate entry for a unique key
+const errDuplicateEntry = 1062
+
+func buildQuery(DBName, idColumnName string, conditions []string, since_id, max_id, limit *uint64) (string, []interface{}) {
 	queryParts := []string{"select * from " + DBName}
 	var args []interface{}
 
-	if since_id != nil || max_id != nil {
-		conditions := []string{}
+	if len(conditions) > 0 || since_id != nil || max_id != nil {
 
 		if since_id != nil {
 			conditions = append(conditions, idColumnName+" >= ?")
@@ -34,3 +38,33 @@ func buildQuery(DBName, idColumnName string, since_id, max_id, limit *uint64) (s
 	query := strings.Join(queryParts, " ")
 	return query, args
 }
+
+// クエリパラメータの真偽値 (0以外なら真)
+func isTrue(flag *uint64) bool {
+	return flag != nil && *flag != 0
+}
+
+// limit と offset を付ける。offset は limit がある場合だけ付けられる
+func appendPage(query string, args []interface{}, offset, limit *uint64) (string, []interface{}) {
+	if limit == nil {
+		return query, args
+	}
+	query += " limit ?"
+	args = append(args, *limit)
+	if offset != nil {
+		query += " offset ?"
+		args = append(args, *offset)
+	}
+	return query, args
+}
+
+// like の検索パターンで文字どおりに扱われるようエスケープする
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// 一意キーの重複によるエラーか
+func isDuplicateEntry(err error) bool {
+	mysqlErr, ok := err.(*mysql.MySQLError)
+	return ok && mysqlErr.Number == errDuplicateEntry
+}
@@ -2,11 +2,20 @@ package object
 
 import (
 	"fmt"
+	"unicode/utf8"
 
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	// Maximum number of characters of display name
+	MaxDisplayNameLength = 30
+
+	// Maximum number of characters of note
+	MaxNoteLength = 500
+)
+
 type (
 	AccountID    = uint64
 	PasswordHash = string
@@ -40,6 +49,9 @@ type (
 		// The number of followers for the account
 		FollowersCount AccountID `json:"followers_count" db:"followers_count"`
 
+		// Whether the account manually approves follow requests
+		Locked bool `json:"locked" db:"locked"`
+
 		// The time the account was created
 		CreateAt DateTime `json:"create_at,omitempty" db:"create_at"`
 	}
@@ -60,6 +72,24 @@ func (a *Account) SetPassword(pass string) error {
 	return nil
 }
 
+// Validate display name and set it to account object
+func (a *Account) SetDisplayName(name string) error {
+	if utf8.RuneCountInString(name) > MaxDisplayNameLength {
+		return fmt.Errorf("display_name must be at most %d characters", MaxDisplayNameLength)
+	}
+	a.DisplayName = &name
+	return nil
+}
+
+// Validate note and set it to account object
+func (a *Account) SetNote(note string) error {
+	if utf8.RuneCountInString(note) > MaxNoteLength {
+		return fmt.Errorf("note must be at most %d characters", MaxNoteLength)
+	}
+	a.Note = &note
+	return nil
+}
+
 func generatePasswordHash(pass string) (PasswordHash, error) {
 	hash, err := bcrypt.GenerateFromPassword([]byte(pass), bcrypt.DefaultCost)
 	if err != nil {
@@ -0,0 +1,72 @@
+package object
+
+import (
+	"strings"
+)
+
+const (
+	clientIDBytes     = 16
+	clientSecretBytes = 32
+
+	// Redirect URI telling the server to show the code instead of redirecting
+	RedirectURIOutOfBand = "urn:ietf:wg:oauth:2.0:oob"
+)
+
+type (
+	Application struct {
+		// The internal ID of the application
+		ID uint64 `json:"id"`
+
+		// The name of the application
+		Name string `json:"name"`
+
+		// The website of the application
+		Website *string `json:"website"`
+
+		// Newline separated URIs the user may be redirected to after authorization
+		RedirectURI string `json:"redirect_uri" db:"redirect_uri"`
+
+		// Space separated OAuth scopes the application may request
+		Scopes string `json:"-"`
+
+		// The public identifier of the application
+		ClientID string `json:"client_id" db:"client_id"`
+
+		// The secret the application authenticates with
+		ClientSecret string `json:"client_secret" db:"client_secret"`
+
+		// The time the application was registered
+		CreateAt DateTime `json:"-" db:"create_at"`
+	}
+)
+
+// Create application with newly generated client credentials
+func NewApplication(name string, redirectURIs []string, scopes string, website *string) (*Application, error) {
+	clientID, err := randomString(clientIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	clientSecret, err := randomString(clientSecretBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Application{
+		Name:         name,
+		Website:      website,
+		RedirectURI:  strings.Join(redirectURIs, "\n"),
+		Scopes:       scopes,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	}, nil
+}
+
+// Check if uri is one of the registered redirect URIs
+func (a *Application) HasRedirectURI(uri string) bool {
+	for _, registered := range strings.Split(a.RedirectURI, "\n") {
+		if registered == uri {
+			return true
+		}
+	}
+	return false
+}
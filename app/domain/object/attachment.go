@@ -0,0 +1,57 @@
+package object
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+const (
+	// Types of media attachments
+	AttachmentTypeImage   = "image"
+	AttachmentTypeVideo   = "video"
+	AttachmentTypeGifv    = "gifv"
+	AttachmentTypeUnknown = "unknown"
+
+	// Maximum number of characters of attachment description
+	MaxAttachmentDescriptionLength = 420
+
+	// Maximum number of attachments on a status
+	MaxStatusAttachments = 4
+)
+
+type (
+	Attachment struct {
+		// The ID of the attachment
+		ID uint64 `json:"id"`
+
+		// The internal ID of the account which uploaded the attachment
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// The internal ID of the status the attachment belongs to, nil until posted
+		StatusID *uint64 `json:"-" db:"status_id"`
+
+		// The internal ID of the scheduled status the attachment is reserved for
+		ScheduledStatusID *uint64 `json:"-" db:"scheduled_status_id"`
+
+		// One of image, video, gifv or unknown
+		Type string `json:"type"`
+
+		// URL of the media
+		URL string `json:"url"`
+
+		// A description of the media for the visually impaired
+		Description *string `json:"description"`
+
+		// The time the attachment was uploaded
+		CreateAt DateTime `json:"-" db:"create_at"`
+	}
+)
+
+// Validate description and set it to attachment object
+func (a *Attachment) SetDescription(description string) error {
+	if utf8.RuneCountInString(description) > MaxAttachmentDescriptionLength {
+		return fmt.Errorf("description must be at most %d characters", MaxAttachmentDescriptionLength)
+	}
+	a.Description = &description
+	return nil
+}
@@ -0,0 +1,91 @@
+package object
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"time"
+)
+
+const (
+	authorizationCodeBytes = 32
+
+	// PKCE code challenge methods (RFC 7636)
+	CodeChallengeMethodPlain = "plain"
+	CodeChallengeMethodS256  = "S256"
+)
+
+type (
+	AuthorizationCode struct {
+		// The internal ID of the authorization code
+		ID uint64 `json:"-"`
+
+		// The one-time code handed to the client
+		Code string `json:"code"`
+
+		// The internal ID of the application the code was issued to
+		ApplicationID uint64 `json:"-" db:"application_id"`
+
+		// The internal ID of the account which approved the authorization
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// The redirect URI the code was issued for
+		RedirectURI string `json:"-" db:"redirect_uri"`
+
+		// Space separated OAuth scopes approved by the account
+		Scopes string `json:"-"`
+
+		// PKCE code challenge, empty if the client did not use PKCE
+		CodeChallenge string `json:"-" db:"code_challenge"`
+
+		// PKCE code challenge method
+		CodeChallengeMethod string `json:"-" db:"code_challenge_method"`
+
+		// The time the code stops being accepted
+		ExpiresAt DateTime `json:"-" db:"expires_at"`
+
+		// The time the code was created
+		CreateAt DateTime `json:"-" db:"create_at"`
+	}
+)
+
+// Issue a new authorization code which is valid for lifetime
+func NewAuthorizationCode(app *Application, accountID AccountID, redirectURI, scopes string, lifetime time.Duration) (*AuthorizationCode, error) {
+	code, err := randomString(authorizationCodeBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &AuthorizationCode{
+		Code:          code,
+		ApplicationID: app.ID,
+		AccountID:     accountID,
+		RedirectURI:   redirectURI,
+		Scopes:        scopes,
+		ExpiresAt:     DateTime{now.Add(lifetime)},
+		CreateAt:      DateTime{now},
+	}, nil
+}
+
+// Check if the code is no longer valid at the given time
+func (c *AuthorizationCode) IsExpired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt.Time)
+}
+
+// Check the PKCE code verifier against the stored challenge
+func (c *AuthorizationCode) VerifyCodeVerifier(verifier string) bool {
+	if c.CodeChallenge == "" {
+		return true
+	}
+	if verifier == "" {
+		return false
+	}
+
+	expected := verifier
+	if c.CodeChallengeMethod == CodeChallengeMethodS256 {
+		sum := sha256.Sum256([]byte(verifier))
+		expected = base64.RawURLEncoding.EncodeToString(sum[:])
+	}
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(c.CodeChallenge)) == 1
+}
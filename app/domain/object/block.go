@@ -0,0 +1,20 @@
+package object
+
+type (
+	Block struct {
+		// The ID of the block
+		ID uint64 `json:"id"`
+
+		// The internal ID of the account which is blocking
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// The internal ID of the blocked account
+		TargetAccountID AccountID `json:"-" db:"target_account_id"`
+
+		// The blocked account
+		Account *Account `json:"account,omitempty" db:"-"`
+
+		// The time the account was blocked
+		CreateAt DateTime `json:"create_at,omitempty" db:"create_at"`
+	}
+)
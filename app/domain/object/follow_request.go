@@ -0,0 +1,21 @@
+package object
+
+type (
+	// A follow waiting for approval by a locked account
+	FollowRequest struct {
+		// The ID of the follow request
+		ID uint64 `json:"id"`
+
+		// The internal ID of the account which requested to follow
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// The internal ID of the account requested to be followed
+		TargetAccountID AccountID `json:"-" db:"target_account_id"`
+
+		// The account which requested to follow
+		Account *Account `json:"account,omitempty" db:"-"`
+
+		// The time the follow was requested
+		CreateAt DateTime `json:"create_at,omitempty" db:"create_at"`
+	}
+)
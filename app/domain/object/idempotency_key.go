@@ -0,0 +1,35 @@
+package object
+
+import (
+	"time"
+)
+
+const (
+	// How long a response is replayed for requests with the same key
+	IdempotencyKeyLifetime = time.Hour
+
+	// How long a request may be in flight before its key is considered abandoned
+	IdempotencyKeyInFlightTimeout = time.Minute
+
+	// Maximum length of `Idempotency-Key` header
+	MaxIdempotencyKeyLength = 255
+)
+
+type (
+	IdempotencyKey struct {
+		// The internal ID of the key
+		ID uint64 `json:"-"`
+
+		// The internal ID of the account which sent the request
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// The value of `Idempotency-Key` header
+		Key string `json:"-" db:"idempotency_key"`
+
+		// The body of the original response, nil while the request is in flight
+		Response *string `json:"-"`
+
+		// The time the first request was received
+		CreateAt DateTime `json:"-" db:"create_at"`
+	}
+)
@@ -0,0 +1,18 @@
+package object
+
+type (
+	// An account mentioned in a status
+	Mention struct {
+		// The internal ID of the status
+		StatusID uint64 `json:"-" db:"status_id"`
+
+		// The internal ID of the mentioned account
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// The username of the mentioned account
+		Username string `json:"username"`
+
+		// Location of the mentioned account's profile
+		URL string `json:"url" db:"-"`
+	}
+)
@@ -0,0 +1,43 @@
+package object
+
+import (
+	"time"
+)
+
+type (
+	Mute struct {
+		// The ID of the mute
+		ID uint64 `json:"id"`
+
+		// The internal ID of the account which is muting
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// The internal ID of the muted account
+		TargetAccountID AccountID `json:"-" db:"target_account_id"`
+
+		// The muted account
+		Account *Account `json:"account,omitempty" db:"-"`
+
+		// Whether notifications from the muted account are hidden too
+		HideNotifications bool `json:"notifications" db:"hide_notifications"`
+
+		// The time the mute lapses, null if it lasts until unmuted
+		ExpiresAt *DateTime `json:"expires_at" db:"expires_at"`
+
+		// The time the account was muted
+		CreateAt DateTime `json:"create_at,omitempty" db:"create_at"`
+	}
+)
+
+// Create a mute lasting duration from now, or until unmuted if duration is 0
+func NewMute(accountID, targetID AccountID, hideNotifications bool, duration time.Duration, now time.Time) *Mute {
+	mute := &Mute{
+		AccountID:         accountID,
+		TargetAccountID:   targetID,
+		HideNotifications: hideNotifications,
+	}
+	if duration > 0 {
+		mute.ExpiresAt = &DateTime{now.Add(duration)}
+	}
+	return mute
+}
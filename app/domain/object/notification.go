@@ -0,0 +1,68 @@
+package object
+
+const (
+	// Someone followed the account
+	NotificationFollow = "follow"
+
+	// Someone mentioned the account in a status
+	NotificationMention = "mention"
+
+	// Someone favourited a status of the account
+	NotificationFavourite = "favourite"
+
+	// Someone reblogged a status of the account
+	NotificationReblog = "reblog"
+)
+
+var notificationTypes = map[string]bool{
+	NotificationFollow:    true,
+	NotificationMention:   true,
+	NotificationFavourite: true,
+	NotificationReblog:    true,
+}
+
+type (
+	// Something another account did to the account
+	Notification struct {
+		// The ID of the notification
+		ID uint64 `json:"id"`
+
+		// The internal ID of the notified account
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// The internal ID of the account which caused the notification
+		FromAccountID AccountID `json:"-" db:"from_account_id"`
+
+		// What happened: follow, mention, favourite or reblog
+		Type string `json:"type"`
+
+		// The internal ID of the status, null for follow
+		StatusID *uint64 `json:"-" db:"status_id"`
+
+		// status_id, or 0 for follow, so that the same follow is notified only once
+		StatusKey uint64 `json:"-" db:"status_key"`
+
+		// The account which caused the notification
+		Account *Account `json:"account,omitempty" db:"-"`
+
+		// The status mentioning the account, or the status favourited or reblogged
+		Status *Status `json:"status,omitempty" db:"-"`
+
+		// The time the notification was created
+		CreateAt DateTime `json:"create_at,omitempty" db:"create_at"`
+	}
+
+	// Types of notifications to list
+	NotificationFilter struct {
+		// Notifications must be one of these types, any type if empty
+		Types []string
+
+		// Notifications must be none of these types
+		ExcludeTypes []string
+	}
+)
+
+// Check if s is a known notification type
+func IsNotificationType(s string) bool {
+	return notificationTypes[s]
+}
@@ -1,7 +1,9 @@
 package object_test
 
 import (
+	"strings"
 	"testing"
+	"time"
 	"yatter-backend-go/app/domain/object"
 )
 
@@ -55,3 +57,362 @@ func TestCheckPassword(t *testing.T) {
 		})
 	}
 }
+
+func TestScopesAllow(t *testing.T) {
+	tests := []struct {
+		granted  string
+		required string
+		want     bool
+	}{
+		{granted: "read", required: "read:statuses", want: true},
+		{granted: "read write", required: "write:media", want: true},
+		{granted: "read:statuses", required: "read:accounts", want: false},
+		{granted: "follow", required: "write:follows", want: true},
+		{granted: "read", required: "write:statuses", want: false},
+		{granted: "", required: "read", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.granted+"/"+tt.required, func(t *testing.T) {
+			if got := object.ScopesAllow(tt.granted, tt.required); got != tt.want {
+				t.Fatalf("expected %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestVerifyCodeVerifier(t *testing.T) {
+	// Example from RFC 7636 Appendix B
+	code := &object.AuthorizationCode{
+		CodeChallenge:       "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+		CodeChallengeMethod: object.CodeChallengeMethodS256,
+	}
+
+	if !code.VerifyCodeVerifier("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk") {
+		t.Fatalf("expected verifier to match")
+	}
+	if code.VerifyCodeVerifier("wrong") {
+		t.Fatalf("expected verifier not to match")
+	}
+}
+
+func TestStatusVisibleTo(t *testing.T) {
+	author := &object.Account{ID: 1}
+	recipient := &object.Account{ID: 2}
+	other := &object.Account{ID: 3}
+	recipientID := recipient.ID
+
+	tests := []struct {
+		name       string
+		visibility string
+		viewer     *object.Account
+		following  bool
+		mentioned  bool
+		want       bool
+	}{
+		{name: "public/anonymous", visibility: object.VisibilityPublic, want: true},
+		{name: "unlisted/anonymous", visibility: object.VisibilityUnlisted, want: true},
+		{name: "private/anonymous", visibility: object.VisibilityPrivate, want: false},
+		{name: "private/author", visibility: object.VisibilityPrivate, viewer: author, want: true},
+		{name: "private/follower", visibility: object.VisibilityPrivate, viewer: other, following: true, want: true},
+		{name: "private/not follower", visibility: object.VisibilityPrivate, viewer: other, want: false},
+		{name: "direct/author", visibility: object.VisibilityDirect, viewer: author, want: true},
+		{name: "direct/recipient", visibility: object.VisibilityDirect, viewer: recipient, want: true},
+		{name: "direct/follower", visibility: object.VisibilityDirect, viewer: other, following: true, want: false},
+		{name: "direct/mentioned", visibility: object.VisibilityDirect, viewer: other, mentioned: true, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := &object.Status{AccountId: author.ID, Visibility: tt.visibility, InReplyToAccountID: &recipientID}
+			if got := status.VisibleTo(tt.viewer, tt.following, tt.mentioned); got != tt.want {
+				t.Fatalf("expected %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestStatusSetSpoilerText(t *testing.T) {
+	tests := []struct {
+		name          string
+		spoilerText   string
+		wantSensitive bool
+		wantErr       bool
+	}{
+		{name: "empty", spoilerText: "", wantSensitive: false},
+		{name: "content warning", spoilerText: "ネタバレ", wantSensitive: true},
+		{name: "max length", spoilerText: strings.Repeat("あ", object.MaxSpoilerTextLength), wantSensitive: true},
+		{name: "too long", spoilerText: strings.Repeat("あ", object.MaxSpoilerTextLength+1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := new(object.Status)
+			err := status.SetSpoilerText(tt.spoilerText)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status.SpoilerText != tt.spoilerText || status.Sensitive != tt.wantSensitive {
+				t.Fatalf("expected (%q, %v), but got (%q, %v)", tt.spoilerText, tt.wantSensitive, status.SpoilerText, status.Sensitive)
+			}
+		})
+	}
+}
+
+func TestNewPoll(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		options   []string
+		expiresIn time.Duration
+		wantErr   bool
+	}{
+		{name: "valid", options: []string{"yes", "no"}, expiresIn: time.Hour},
+		{name: "too few options", options: []string{"yes"}, expiresIn: time.Hour, wantErr: true},
+		{name: "too many options", options: []string{"a", "b", "c", "d", "e"}, expiresIn: time.Hour, wantErr: true},
+		{name: "empty option", options: []string{"yes", ""}, expiresIn: time.Hour, wantErr: true},
+		{name: "too long option", options: []string{"yes", strings.Repeat("あ", object.MaxPollOptionLength+1)}, expiresIn: time.Hour, wantErr: true},
+		{name: "duplicated option", options: []string{"yes", "yes"}, expiresIn: time.Hour, wantErr: true},
+		{name: "too short", options: []string{"yes", "no"}, expiresIn: time.Minute, wantErr: true},
+		{name: "too long", options: []string{"yes", "no"}, expiresIn: object.MaxPollExpiresIn + time.Second, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poll, err := object.NewPoll(tt.options, tt.expiresIn, false, false, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(poll.Options) != len(tt.options) || !poll.ExpiresAt.Equal(now.Add(tt.expiresIn)) {
+				t.Fatalf("unexpected poll %+v", poll)
+			}
+			if poll.IsExpired(now) || !poll.IsExpired(now.Add(tt.expiresIn)) {
+				t.Fatal("poll should expire exactly after expires_in")
+			}
+		})
+	}
+}
+
+func TestPollValidateChoices(t *testing.T) {
+	single := &object.Poll{Options: make([]*object.PollOption, 3)}
+	multiple := &object.Poll{Options: make([]*object.PollOption, 3), Multiple: true}
+
+	tests := []struct {
+		name    string
+		poll    *object.Poll
+		choices []int
+		wantErr bool
+	}{
+		{name: "single", poll: single, choices: []int{2}},
+		{name: "multiple", poll: multiple, choices: []int{0, 2}},
+		{name: "empty", poll: single, choices: []int{}, wantErr: true},
+		{name: "multiple on single choice poll", poll: single, choices: []int{0, 1}, wantErr: true},
+		{name: "out of range", poll: single, choices: []int{3}, wantErr: true},
+		{name: "negative", poll: single, choices: []int{-1}, wantErr: true},
+		{name: "duplicated", poll: multiple, choices: []int{1, 1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.poll.ValidateChoices(tt.choices)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, but got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestScheduledStatusSetScheduledAt(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		scheduledAt time.Time
+		wantErr     bool
+	}{
+		{name: "far enough", scheduledAt: now.Add(object.MinScheduleLead)},
+		{name: "too soon", scheduledAt: now.Add(object.MinScheduleLead - time.Second), wantErr: true},
+		{name: "past", scheduledAt: now.Add(-time.Hour), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduled := new(object.ScheduledStatus)
+			err := scheduled.SetScheduledAt(tt.scheduledAt, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, but got %v", tt.wantErr, err)
+			}
+			if !tt.wantErr && !scheduled.ScheduledAt.Equal(tt.scheduledAt) {
+				t.Fatalf("expected %v, but got %v", tt.scheduledAt, scheduled.ScheduledAt)
+			}
+		})
+	}
+}
+
+func TestScheduledStatusParamsValue(t *testing.T) {
+	inReplyToID := uint64(5)
+	params := object.ScheduledStatusParams{
+		Text:        "test post",
+		MediaIDs:    []uint64{1, 2},
+		InReplyToID: &inReplyToID,
+		Visibility:  object.VisibilityPrivate,
+		Poll:        &object.PollParams{Options: []string{"yes", "no"}, ExpiresIn: 300},
+	}
+
+	value, err := params.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got object.ScheduledStatusParams
+	if err := got.Scan([]byte(value.(string))); err != nil {
+		t.Fatal(err)
+	}
+	if got.Text != params.Text || len(got.MediaIDs) != 2 || *got.InReplyToID != inReplyToID || got.Visibility != params.Visibility || got.Poll.ExpiresIn != 300 {
+		t.Fatalf("expected %+v, but got %+v", params, got)
+	}
+}
+
+func TestStatusSetText(t *testing.T) {
+	alice := &object.Account{ID: 2, Username: "alice"}
+
+	tests := []struct {
+		name         string
+		text         string
+		wantContent  string
+		wantMentions []string
+		wantTags     []string
+	}{
+		{
+			name:        "empty",
+			text:        "",
+			wantContent: "",
+		},
+		{
+			name:        "escape",
+			text:        "<script>alert(1)</script> & \"quote\"",
+			wantContent: "<p>&lt;script&gt;alert(1)&lt;/script&gt; &amp; &#34;quote&#34;</p>",
+		},
+		{
+			name:        "paragraphs",
+			text:        "line1\nline2\n\nparagraph2",
+			wantContent: "<p>line1<br>line2</p><p>paragraph2</p>",
+		},
+		{
+			name:         "mention",
+			text:         "hi @Alice and @alice, not @bob or mail@alice",
+			wantContent:  `<p>hi <span class="h-card"><a href="/v1/accounts/alice" class="u-url mention">@<span>alice</span></a></span> and <span class="h-card"><a href="/v1/accounts/alice" class="u-url mention">@<span>alice</span></a></span>, not @bob or mail@alice</p>`,
+			wantMentions: []string{"alice"},
+		},
+		{
+			name:        "tag",
+			text:        "#Go と #日本語 と #golang #123",
+			wantContent: `<p><a href="/v1/timelines/tag/go" class="mention hashtag" rel="tag">#<span>Go</span></a> と <a href="/v1/timelines/tag/%E6%97%A5%E6%9C%AC%E8%AA%9E" class="mention hashtag" rel="tag">#<span>日本語</span></a> と <a href="/v1/timelines/tag/golang" class="mention hashtag" rel="tag">#<span>golang</span></a> #123</p>`,
+			wantTags:    []string{"go", "日本語", "golang"},
+		},
+		{
+			name:        "url",
+			text:        "see https://example.com/a?b=1&c=2#top.",
+			wantContent: `<p>see <a href="https://example.com/a?b=1&amp;c=2#top" rel="nofollow noopener noreferrer" target="_blank">https://example.com/a?b=1&amp;c=2#top</a>.</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := new(object.Status)
+			status.SetText(tt.text, []*object.Account{alice})
+			if status.Content != tt.wantContent {
+				t.Fatalf("expected content %q, but got %q", tt.wantContent, status.Content)
+			}
+			if status.Source != tt.text {
+				t.Fatalf("expected source %q, but got %q", tt.text, status.Source)
+			}
+			if len(status.Mentions) != len(tt.wantMentions) {
+				t.Fatalf("expected %d mentions, but got %d", len(tt.wantMentions), len(status.Mentions))
+			}
+			for i, mention := range status.Mentions {
+				if mention.Username != tt.wantMentions[i] || mention.AccountID != alice.ID {
+					t.Fatalf("unexpected mention %+v", mention)
+				}
+			}
+			if len(status.Tags) != len(tt.wantTags) {
+				t.Fatalf("expected %d tags, but got %d", len(tt.wantTags), len(status.Tags))
+			}
+			for i, tag := range status.Tags {
+				if tag.Name != tt.wantTags[i] {
+					t.Fatalf("expected tag %q, but got %q", tt.wantTags[i], tag.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestParseMentions(t *testing.T) {
+	got := object.ParseMentions("@alice @Alice @bob mail@carol https://example.com/@dave")
+	want := []string{"alice", "bob"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, but got %v", want, got)
+	}
+}
+
+func TestTagTrends(t *testing.T) {
+	now := time.Now()
+	activities := []*object.TagActivity{
+		{TagID: 1, Name: "rising", DaysAgo: 0, Uses: 5, Accounts: 4},
+		{TagID: 1, Name: "rising", DaysAgo: 3, Uses: 6, Accounts: 6},
+		{TagID: 2, Name: "new", DaysAgo: 0, Uses: 2, Accounts: 2},
+		{TagID: 3, Name: "steady", DaysAgo: 0, Uses: 1, Accounts: 1},
+		{TagID: 3, Name: "steady", DaysAgo: 1, Uses: 6, Accounts: 6},
+		{TagID: 4, Name: "old", DaysAgo: object.TrendHistoryDays, Uses: 9, Accounts: 9},
+	}
+
+	trends := object.TagTrends(activities, now)
+	if len(trends) != 2 {
+		t.Fatalf("expected 2 trends, but got %d", len(trends))
+	}
+	if trends[0].TagID != 1 || trends[0].Score != 3 {
+		t.Fatalf("expected tag 1 with score 3 first, but got %+v", trends[0])
+	}
+	if trends[1].TagID != 2 || trends[1].Score != 2 {
+		t.Fatalf("expected tag 2 with score 2 second, but got %+v", trends[1])
+	}
+	history := trends[0].History
+	if len(history) != object.TrendHistoryDays {
+		t.Fatalf("expected %d days of history, but got %d", object.TrendHistoryDays, len(history))
+	}
+	if history[0].Uses != 5 || history[3].Accounts != 6 || history[1].Uses != 0 {
+		t.Fatalf("unexpected history %+v %+v %+v", history[0], history[1], history[3])
+	}
+	if !history[0].Day.Time.Equal(now.Add(-object.TrendWindow)) {
+		t.Fatalf("expected the latest day to start %v, but got %v", now.Add(-object.TrendWindow), history[0].Day.Time)
+	}
+}
+
+func TestStatusTrends(t *testing.T) {
+	now := time.Now()
+	activities := []*object.StatusActivity{
+		{StatusID: 1, CreateAt: object.DateTime{Time: now.Add(-48 * time.Hour)}, Interactions: 10},
+		{StatusID: 2, CreateAt: object.DateTime{Time: now.Add(-time.Hour)}, Interactions: 3},
+		{StatusID: 3, CreateAt: object.DateTime{Time: now.Add(-object.MaxTrendingStatusAge - time.Hour)}, Interactions: 100},
+	}
+
+	trends := object.StatusTrends(activities, now)
+	if len(trends) != 2 {
+		t.Fatalf("expected 2 trends, but got %d", len(trends))
+	}
+	if trends[0].StatusID != 2 || trends[1].StatusID != 1 {
+		t.Fatalf("expected the newer status first, but got %d, %d", trends[0].StatusID, trends[1].StatusID)
+	}
+}
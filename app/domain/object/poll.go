@@ -0,0 +1,139 @@
+package object
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// Minimum number of options of a poll
+	MinPollOptions = 2
+
+	// Maximum number of options of a poll
+	MaxPollOptions = 4
+
+	// Maximum number of characters of a poll option
+	MaxPollOptionLength = 50
+
+	// Shortest and longest duration a poll can stay open
+	MinPollExpiresIn = 5 * time.Minute
+	MaxPollExpiresIn = 30 * 24 * time.Hour
+)
+
+type (
+	Poll struct {
+		// The ID of the poll
+		ID uint64 `json:"id"`
+
+		// The internal ID of the account which posted the poll
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// The internal ID of the status the poll is attached to
+		StatusID uint64 `json:"-" db:"status_id"`
+
+		// The time the poll stops accepting votes
+		ExpiresAt DateTime `json:"expires_at" db:"expires_at"`
+
+		// Whether the poll is closed
+		Expired bool `json:"expired"`
+
+		// Whether multiple choices are allowed
+		Multiple bool `json:"multiple"`
+
+		// Whether the number of votes of each option is hidden until the poll is closed
+		HideTotals bool `json:"-" db:"hide_totals"`
+
+		// How many votes have been received
+		VotesCount uint64 `json:"votes_count" db:"votes_count"`
+
+		// How many accounts have voted
+		VotersCount uint64 `json:"voters_count" db:"voters_count"`
+
+		// Options to choose from, in the order they were posted
+		Options []*PollOption `json:"options" db:"-"`
+
+		// Whether the viewer has voted
+		Voted bool `json:"voted" db:"-"`
+
+		// Indices of the options the viewer voted for
+		OwnVotes []int `json:"own_votes" db:"-"`
+
+		// The time the poll was created
+		CreateAt DateTime `json:"-" db:"create_at"`
+	}
+
+	PollOption struct {
+		// The internal ID of the option
+		ID uint64 `json:"-"`
+
+		// The internal ID of the poll
+		PollID uint64 `json:"-" db:"poll_id"`
+
+		// Index of the option in the poll, starting from 0
+		Position int `json:"-"`
+
+		// The text of the option
+		Title string `json:"title"`
+
+		// How many votes the option has received, nil while the totals are hidden
+		VotesCount *uint64 `json:"votes_count" db:"votes_count"`
+	}
+)
+
+// Validate options and duration and create a new poll which closes expiresIn after now
+func NewPoll(options []string, expiresIn time.Duration, multiple, hideTotals bool, now time.Time) (*Poll, error) {
+	if len(options) < MinPollOptions || len(options) > MaxPollOptions {
+		return nil, fmt.Errorf("poll must have %d to %d options", MinPollOptions, MaxPollOptions)
+	}
+	if expiresIn < MinPollExpiresIn || expiresIn > MaxPollExpiresIn {
+		return nil, fmt.Errorf("expires_in must be between %d and %d seconds", int64(MinPollExpiresIn.Seconds()), int64(MaxPollExpiresIn.Seconds()))
+	}
+
+	poll := &Poll{
+		ExpiresAt:  DateTime{now.Add(expiresIn)},
+		Multiple:   multiple,
+		HideTotals: hideTotals,
+		OwnVotes:   []int{},
+	}
+	seen := make(map[string]bool, len(options))
+	for i, title := range options {
+		if title == "" || utf8.RuneCountInString(title) > MaxPollOptionLength {
+			return nil, fmt.Errorf("poll option must be 1 to %d characters", MaxPollOptionLength)
+		}
+		if seen[title] {
+			return nil, fmt.Errorf("poll options must be unique")
+		}
+		seen[title] = true
+		var votesCount uint64
+		poll.Options = append(poll.Options, &PollOption{Position: i, Title: title, VotesCount: &votesCount})
+	}
+	return poll, nil
+}
+
+// Check if the poll no longer accepts votes at the given time.
+// It is true as soon as the deadline passes even if the poll has not been closed yet.
+func (p *Poll) IsExpired(now time.Time) bool {
+	return p.Expired || !now.Before(p.ExpiresAt.Time)
+}
+
+// Check if choices are valid indices of the options
+func (p *Poll) ValidateChoices(choices []int) error {
+	if len(choices) == 0 {
+		return fmt.Errorf("choices is required")
+	}
+	if !p.Multiple && len(choices) > 1 {
+		return fmt.Errorf("poll does not allow multiple choices")
+	}
+	seen := make(map[int]bool, len(choices))
+	for _, choice := range choices {
+		if choice < 0 || choice >= len(p.Options) {
+			return fmt.Errorf("choice %d is out of range", choice)
+		}
+		if seen[choice] {
+			return fmt.Errorf("choice %d is duplicated", choice)
+		}
+		seen[choice] = true
+	}
+	return nil
+}
@@ -16,4 +16,31 @@ type (
 		// The time the relationship was created
 		CreateAt DateTime `json:"create_at,omitempty" db:"create_at"`
 	}
+
+	// Relationship between the viewer and a target account
+	AccountRelationship struct {
+		// The internal ID of the target account
+		ID AccountID `json:"id" db:"id"`
+
+		// The username of the target account
+		Username string `json:"-" db:"username"`
+
+		// Whether the viewer is following the target account
+		Following bool `json:"following" db:"following"`
+
+		// Whether the viewer is followed by the target account
+		FollowedBy bool `json:"followed_by" db:"followed_by"`
+
+		// Whether the viewer's follow request is waiting for approval
+		Requested bool `json:"requested" db:"requested"`
+
+		// Whether the viewer is blocking the target account
+		Blocking bool `json:"blocking" db:"blocking"`
+
+		// Whether the viewer is muting the target account
+		Muting bool `json:"muting" db:"muting"`
+
+		// Whether the viewer is muting notifications from the target account
+		MutingNotifications bool `json:"muting_notifications" db:"muting_notifications"`
+	}
 )
@@ -0,0 +1,91 @@
+package object
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// How far in the future a status must be scheduled
+const MinScheduleLead = 5 * time.Minute
+
+type (
+	ScheduledStatus struct {
+		// The ID of the scheduled status
+		ID uint64 `json:"id"`
+
+		// The internal ID of the account which scheduled the status
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// The time the status will be published
+		ScheduledAt DateTime `json:"scheduled_at" db:"scheduled_at"`
+
+		// Parameters the status will be posted with
+		Params ScheduledStatusParams `json:"params"`
+
+		// Media reserved for the status
+		MediaAttachments []*Attachment `json:"media_attachments" db:"-"`
+
+		// Identifies the scheduler instance currently publishing the status
+		LeaseToken *string `json:"-" db:"lease_token"`
+
+		// The time the lease is released if the scheduler does not finish publishing
+		LeaseExpiresAt *DateTime `json:"-" db:"lease_expires_at"`
+
+		// Why the status could not be published, nil unless it failed.
+		// A failed status is not retried until it is rescheduled.
+		Failure *string `json:"failure" db:"failure"`
+
+		// The time the status was scheduled
+		CreateAt DateTime `json:"-" db:"create_at"`
+	}
+
+	// Parameters of `POST /v1/statuses` stored until the status is published
+	ScheduledStatusParams struct {
+		Text        string      `json:"text"`
+		MediaIDs    []uint64    `json:"media_ids"`
+		InReplyToID *uint64     `json:"in_reply_to_id"`
+		Visibility  string      `json:"visibility"`
+		SpoilerText string      `json:"spoiler_text"`
+		Sensitive   bool        `json:"sensitive"`
+		Poll        *PollParams `json:"poll"`
+	}
+
+	// Parameters of a poll, which starts when the status is published
+	PollParams struct {
+		Options    []string `json:"options"`
+		ExpiresIn  int64    `json:"expires_in"`
+		Multiple   bool     `json:"multiple"`
+		HideTotals bool     `json:"hide_totals"`
+	}
+)
+
+// Validate the time and set it to scheduled status object
+func (s *ScheduledStatus) SetScheduledAt(scheduledAt time.Time, now time.Time) error {
+	if scheduledAt.Before(now.Add(MinScheduleLead)) {
+		return fmt.Errorf("scheduled_at must be at least %d minutes in the future", int(MinScheduleLead.Minutes()))
+	}
+	s.ScheduledAt = DateTime{scheduledAt}
+	return nil
+}
+
+// database/sql/driver/Valuer
+func (p ScheduledStatusParams) Value() (driver.Value, error) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// database/sql/Scanner
+func (p *ScheduledStatusParams) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, p)
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	}
+	return fmt.Errorf("cannot scan %T into ScheduledStatusParams", value)
+}
@@ -0,0 +1,102 @@
+package object
+
+import (
+	"strings"
+)
+
+// OAuth scopes granted to an access token
+const (
+	ScopeRead               = "read"
+	ScopeReadAccounts       = "read:accounts"
+	ScopeReadStatuses       = "read:statuses"
+	ScopeReadFollows        = "read:follows"
+	ScopeReadFavourites     = "read:favourites"
+	ScopeReadBlocks         = "read:blocks"
+	ScopeReadMutes          = "read:mutes"
+	ScopeReadNotifications  = "read:notifications"
+	ScopeWrite              = "write"
+	ScopeWriteAccounts      = "write:accounts"
+	ScopeWriteStatuses      = "write:statuses"
+	ScopeWriteMedia         = "write:media"
+	ScopeWriteFollows       = "write:follows"
+	ScopeWriteFavourites    = "write:favourites"
+	ScopeWriteBlocks        = "write:blocks"
+	ScopeWriteMutes         = "write:mutes"
+	ScopeWriteNotifications = "write:notifications"
+	ScopeFollow             = "follow"
+
+	// Scopes given when a client does not ask for anything specific
+	DefaultScopes = ScopeRead
+
+	// Scopes covering every API, used for the password grant
+	FullScopes = ScopeRead + " " + ScopeWrite + " " + ScopeFollow
+)
+
+var knownScopes = map[string]bool{
+	ScopeRead:               true,
+	ScopeReadAccounts:       true,
+	ScopeReadStatuses:       true,
+	ScopeReadFollows:        true,
+	ScopeReadFavourites:     true,
+	ScopeReadBlocks:         true,
+	ScopeReadMutes:          true,
+	ScopeReadNotifications:  true,
+	ScopeWrite:              true,
+	ScopeWriteAccounts:      true,
+	ScopeWriteStatuses:      true,
+	ScopeWriteMedia:         true,
+	ScopeWriteFollows:       true,
+	ScopeWriteFavourites:    true,
+	ScopeWriteBlocks:        true,
+	ScopeWriteMutes:         true,
+	ScopeWriteNotifications: true,
+	ScopeFollow:             true,
+}
+
+// `follow` is the legacy umbrella scope for relationship operations
+var followScopes = map[string]bool{
+	ScopeReadFollows:  true,
+	ScopeWriteFollows: true,
+	ScopeReadBlocks:   true,
+	ScopeWriteBlocks:  true,
+	ScopeReadMutes:    true,
+	ScopeWriteMutes:   true,
+}
+
+// Split space separated scopes, rejecting unknown ones
+func ParseScopes(s string) ([]string, bool) {
+	scopes := strings.Fields(s)
+	for _, scope := range scopes {
+		if !knownScopes[scope] {
+			return nil, false
+		}
+	}
+	return scopes, true
+}
+
+// Check if every scope in requested is permitted by granted
+func ScopesSubset(requested, granted string) bool {
+	for _, scope := range strings.Fields(requested) {
+		if !ScopesAllow(granted, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// Check if space separated granted scopes permit the required scope
+func ScopesAllow(granted, required string) bool {
+	for _, scope := range strings.Fields(granted) {
+		if scope == required {
+			return true
+		}
+		// `read` covers `read:*` and `write` covers `write:*`
+		if strings.HasPrefix(required, scope+":") {
+			return true
+		}
+		if scope == ScopeFollow && followScopes[required] {
+			return true
+		}
+	}
+	return false
+}
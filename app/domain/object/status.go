@@ -1,19 +1,170 @@
 package object
 
-import ()
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+const (
+	// Visible to everyone and shown in public timelines
+	VisibilityPublic = "public"
+
+	// Visible to everyone but not shown in public timelines
+	VisibilityUnlisted = "unlisted"
+
+	// Visible to followers only
+	VisibilityPrivate = "private"
+
+	// Visible to the recipient only
+	VisibilityDirect = "direct"
+
+	// Maximum number of characters of content warning
+	MaxSpoilerTextLength = 255
+)
 
 type (
 	Status struct {
-		// The internal ID of the status
-		ID AccountID `json:"-"`
+		// The ID of the status
+		ID AccountID `json:"id"`
 
 		// The internal ID of the account
-		AccountId AccountID `json:"account_id" db:"account_id"`
+		AccountId AccountID `json:"-" db:"account_id"`
+
+		// The account which posted the status
+		Account *Account `json:"account,omitempty" db:"-"`
 
-		// The content of the status
+		// The content of the status, rendered into HTML
 		Content string `json:"content"`
 
+		// The source text the content was rendered from
+		Source string `json:"-" db:"text"`
+
+		// The source text of the status, only returned when the status is deleted
+		Text *string `json:"text,omitempty" db:"-"`
+
+		// Accounts mentioned in the status
+		Mentions []*Mention `json:"mentions" db:"-"`
+
+		// Hashtags used in the status
+		Tags []*Tag `json:"tags" db:"-"`
+
+		// Text shown as a content warning before the status is expanded
+		SpoilerText string `json:"spoiler_text" db:"spoiler_text"`
+
+		// Whether the status or its media is marked as sensitive
+		Sensitive bool `json:"sensitive"`
+
+		// Who can see the status: public, unlisted, private or direct
+		Visibility string `json:"visibility"`
+
+		// Whether the status has media attachments
+		HasMedia bool `json:"-" db:"has_media"`
+
+		// The internal ID of the poll attached to the status
+		PollID *uint64 `json:"-" db:"poll_id"`
+
+		// The poll attached to the status
+		Poll *Poll `json:"poll" db:"-"`
+
+		// The ID of the status being replied to
+		InReplyToID *uint64 `json:"in_reply_to_id" db:"in_reply_to_id"`
+
+		// The ID of the account that authored the status being replied to
+		InReplyToAccountID *AccountID `json:"in_reply_to_account_id" db:"in_reply_to_account_id"`
+
+		// IDs from the root of the thread to the status, e.g. "1/5/12/"
+		ThreadPath string `json:"-" db:"thread_path"`
+
+		// How many favourites this status has received
+		FavouritesCount uint64 `json:"favourites_count" db:"favourites_count"`
+
+		// Whether the viewer has favourited the status
+		Favourited bool `json:"favourited" db:"-"`
+
+		// The ID of the status being reblogged
+		ReblogOfID *uint64 `json:"-" db:"reblog_of_id"`
+
+		// The status being reblogged
+		Reblog *Status `json:"reblog" db:"-"`
+
+		// How many boosts this status has received
+		ReblogsCount uint64 `json:"reblogs_count" db:"reblogs_count"`
+
+		// Whether the viewer has boosted the status
+		Reblogged bool `json:"reblogged" db:"-"`
+
 		// The time the status was created
 		CreateAt DateTime `json:"create_at,omitempty" db:"create_at"`
+
+		// The time the status was last edited, nil if never edited
+		EditedAt *DateTime `json:"edited_at" db:"edited_at"`
+
+		// Media attached to the status
+		MediaAttachments []*Attachment `json:"media_attachments" db:"-"`
 	}
 )
+
+type (
+	// A revision of a status
+	StatusEdit struct {
+		// The internal ID of the revision
+		ID uint64 `json:"-"`
+
+		// The internal ID of the status
+		StatusID uint64 `json:"-" db:"status_id"`
+
+		// The content of the status at this revision
+		Content string `json:"content"`
+
+		// The account which posted the status
+		Account *Account `json:"account,omitempty" db:"-"`
+
+		// The time the revision was posted
+		CreateAt DateTime `json:"create_at" db:"create_at"`
+	}
+)
+
+// Check if the visibility is one of the known levels
+func IsValidVisibility(visibility string) bool {
+	switch visibility {
+	case VisibilityPublic, VisibilityUnlisted, VisibilityPrivate, VisibilityDirect:
+		return true
+	}
+	return false
+}
+
+// Validate content warning and set it to status object.
+// A status with a content warning is always marked as sensitive.
+func (s *Status) SetSpoilerText(spoilerText string) error {
+	if utf8.RuneCountInString(spoilerText) > MaxSpoilerTextLength {
+		return fmt.Errorf("spoiler_text must be at most %d characters", MaxSpoilerTextLength)
+	}
+	s.SpoilerText = spoilerText
+	if spoilerText != "" {
+		s.Sensitive = true
+	}
+	return nil
+}
+
+// Check if the viewer can see the status.
+// viewer is nil for anonymous requests, following tells whether the viewer follows the author
+// and mentioned tells whether the status mentions the viewer.
+func (s *Status) VisibleTo(viewer *Account, following, mentioned bool) bool {
+	switch s.Visibility {
+	case VisibilityPublic, VisibilityUnlisted:
+		return true
+	}
+	if viewer == nil {
+		return false
+	}
+	if viewer.ID == s.AccountId {
+		return true
+	}
+	switch s.Visibility {
+	case VisibilityPrivate:
+		return following
+	case VisibilityDirect:
+		return mentioned || (s.InReplyToAccountID != nil && *s.InReplyToAccountID == viewer.ID)
+	}
+	return false
+}
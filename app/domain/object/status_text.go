@@ -0,0 +1,153 @@
+package object
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// URLs, mentions and hashtags in the source text of a status
+	textTokenPattern = regexp.MustCompile(`https?://[^\s<>"]+|@[A-Za-z0-9_]+|#[\p{L}\p{N}_]+`)
+
+	// Blank lines separating paragraphs
+	paragraphSeparator = regexp.MustCompile(`\n\s*\n`)
+)
+
+// Location of the profile of the account
+func AccountURL(username string) string {
+	return "/v1/accounts/" + url.PathEscape(username)
+}
+
+// Location of the timeline of the tag
+func TagURL(name string) string {
+	return "/v1/timelines/tag/" + url.PathEscape(name)
+}
+
+// Return the usernames mentioned in text, without duplicates
+func ParseMentions(text string) []string {
+	var usernames []string
+	seen := make(map[string]bool)
+	scanText(text, func(string) {}, func(token string) {
+		if token[0] != '@' {
+			return
+		}
+		if key := strings.ToLower(token[1:]); !seen[key] {
+			seen[key] = true
+			usernames = append(usernames, token[1:])
+		}
+	})
+	return usernames
+}
+
+// Render the source text into HTML content and set mentions and tags found in it.
+// accounts are the accounts the text mentions; mentions of unknown usernames are left as plain text.
+// Everything but the generated links is escaped, so the content is safe to embed.
+func (s *Status) SetText(text string, accounts []*Account) {
+	accountByUsername := make(map[string]*Account, len(accounts))
+	for _, account := range accounts {
+		accountByUsername[strings.ToLower(account.Username)] = account
+	}
+
+	s.Source = text
+	s.Mentions = []*Mention{}
+	s.Tags = []*Tag{}
+	mentioned := make(map[AccountID]bool)
+	tagged := make(map[string]bool)
+
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+	if text == "" {
+		s.Content = ""
+		return
+	}
+
+	var b strings.Builder
+	for _, paragraph := range paragraphSeparator.Split(text, -1) {
+		b.WriteString("<p>")
+		scanText(paragraph, func(plain string) {
+			b.WriteString(strings.ReplaceAll(html.EscapeString(plain), "\n", "<br>"))
+		}, func(token string) {
+			switch token[0] {
+			case '@':
+				account, ok := accountByUsername[strings.ToLower(token[1:])]
+				if !ok {
+					b.WriteString(html.EscapeString(token))
+					return
+				}
+				b.WriteString(`<span class="h-card"><a href="` + html.EscapeString(AccountURL(account.Username)) + `" class="u-url mention">@<span>` + html.EscapeString(account.Username) + `</span></a></span>`)
+				if !mentioned[account.ID] {
+					mentioned[account.ID] = true
+					s.Mentions = append(s.Mentions, &Mention{AccountID: account.ID, Username: account.Username, URL: AccountURL(account.Username)})
+				}
+			case '#':
+				name := NormalizeTagName(token)
+				b.WriteString(`<a href="` + html.EscapeString(TagURL(name)) + `" class="mention hashtag" rel="tag">#<span>` + html.EscapeString(token[1:]) + `</span></a>`)
+				if !tagged[name] {
+					tagged[name] = true
+					s.Tags = append(s.Tags, &Tag{Name: name, URL: TagURL(name)})
+				}
+			default:
+				escaped := html.EscapeString(token)
+				b.WriteString(`<a href="` + escaped + `" rel="nofollow noopener noreferrer" target="_blank">` + escaped + `</a>`)
+			}
+		})
+		b.WriteString("</p>")
+	}
+	s.Content = b.String()
+}
+
+// Call plain for each run of ordinary text and token for each URL, mention and hashtag in text, in order.
+// Mentions and hashtags only count at the start of a word, so e-mail addresses are not mentions.
+func scanText(text string, plain func(string), token func(string)) {
+	last := 0
+	for _, loc := range textTokenPattern.FindAllStringIndex(text, -1) {
+		start, end := loc[0], loc[1]
+		t := text[start:end]
+		switch t[0] {
+		case '@', '#':
+			if r, _ := utf8.DecodeLastRuneInString(text[:start]); start > 0 && (isWordRune(r) || r == '/') {
+				continue
+			}
+			if t[0] == '#' && (isDigits(t[1:]) || utf8.RuneCountInString(t[1:]) > MaxTagLength) {
+				continue
+			}
+		default:
+			t = trimURL(t)
+			end = start + len(t)
+		}
+		plain(text[last:start])
+		token(t)
+		last = end
+	}
+	plain(text[last:])
+}
+
+// Drop punctuation which most likely ends the sentence rather than the URL
+func trimURL(u string) string {
+	for {
+		trimmed := strings.TrimRight(u, `.,:;!?'`)
+		if strings.HasSuffix(trimmed, ")") && strings.Count(trimmed, "(") < strings.Count(trimmed, ")") {
+			trimmed = trimmed[:len(trimmed)-1]
+		}
+		if trimmed == u {
+			return u
+		}
+		u = trimmed
+	}
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+package object
+
+import "strings"
+
+// Maximum number of characters of a hashtag
+const MaxTagLength = 255
+
+type (
+	// A hashtag used in statuses
+	Tag struct {
+		// The internal ID of the tag
+		ID uint64 `json:"-"`
+
+		// The name of the tag without `#`, in lowercase
+		Name string `json:"name"`
+
+		// Location of the tag timeline
+		URL string `json:"url" db:"-"`
+
+		// Usage of the tag in the latest days, only set for trending tags
+		History TagHistories `json:"history,omitempty" db:"history"`
+
+		// The internal ID of the status the tag was used in, only set when retrieved for statuses
+		StatusID uint64 `json:"-" db:"status_id"`
+
+		// The time the tag was first used
+		CreateAt DateTime `json:"-" db:"create_at"`
+	}
+)
+
+type (
+	// Tags which select the statuses of a tag timeline
+	TagFilter struct {
+		// Statuses must use at least one of these tags
+		Any []string
+
+		// Statuses must also use every one of these tags
+		All []string
+
+		// Statuses must use none of these tags
+		None []string
+	}
+)
+
+// Normalize a tag name given by users, with or without `#`, to the stored form
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimPrefix(name, "#"))
+}
@@ -0,0 +1,71 @@
+package object
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+const accessTokenBytes = 32
+
+type (
+	Token struct {
+		// The internal ID of the token
+		ID uint64 `json:"-"`
+
+		// The internal ID of the account the token was issued to
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// The internal ID of the application the token was issued to, if any
+		ApplicationID *uint64 `json:"-" db:"application_id"`
+
+		// The opaque bearer token presented by clients
+		AccessToken string `json:"access_token" db:"token"`
+
+		// Space separated OAuth scopes granted to the token
+		Scopes string `json:"scope" db:"scopes"`
+
+		// The time the token stops being accepted
+		ExpiresAt DateTime `json:"-" db:"expires_at"`
+
+		// The time the token was created
+		CreateAt DateTime `json:"create_at,omitempty" db:"create_at"`
+	}
+)
+
+// Issue a new random token for the account which is valid for lifetime
+func NewToken(accountID AccountID, scopes string, lifetime time.Duration) (*Token, error) {
+	accessToken, err := randomString(accessTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Token{
+		AccountID:   accountID,
+		AccessToken: accessToken,
+		Scopes:      scopes,
+		ExpiresAt:   DateTime{now.Add(lifetime)},
+		CreateAt:    DateTime{now},
+	}, nil
+}
+
+// Check if the token is no longer valid at the given time
+func (t *Token) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt.Time)
+}
+
+// Check if the token was granted the scope
+func (t *Token) Allows(scope string) bool {
+	return ScopesAllow(t.Scopes, scope)
+}
+
+// Generate hex encoded random string from n random bytes
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate random string failed: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
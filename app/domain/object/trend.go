@@ -0,0 +1,166 @@
+package object
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// Length of the sliding windows activity is counted over
+	TrendWindow = 24 * time.Hour
+
+	// Number of windows in the history of a tag.
+	// The latest one is compared with the average of the others.
+	TrendHistoryDays = 7
+
+	// How long a status can trend after it was posted
+	MaxTrendingStatusAge = 7 * 24 * time.Hour
+)
+
+type (
+	// Usage of a tag in one window
+	TagActivity struct {
+		// The internal ID of the tag
+		TagID uint64 `db:"tag_id"`
+
+		// The name of the tag
+		Name string
+
+		// How many windows before now, 0 for the latest one
+		DaysAgo int `db:"days_ago"`
+
+		// How many statuses used the tag
+		Uses uint64
+
+		// How many accounts used the tag
+		Accounts uint64
+	}
+
+	// Usage of a tag in one day
+	TagHistory struct {
+		// The start of the day
+		Day DateTime `json:"day"`
+
+		// How many statuses used the tag
+		Uses uint64 `json:"uses"`
+
+		// How many accounts used the tag
+		Accounts uint64 `json:"accounts"`
+	}
+
+	// Usage of a tag, newest day first
+	TagHistories []*TagHistory
+
+	// A trending tag computed by the trends job
+	TagTrend struct {
+		// The internal ID of the tag
+		TagID uint64 `db:"tag_id"`
+
+		// How much the tag is trending, higher first
+		Score float64
+
+		// Usage of the tag in the latest days
+		History TagHistories
+	}
+
+	// Favourites and reblogs a status received in the latest window
+	StatusActivity struct {
+		// The internal ID of the status
+		StatusID uint64 `db:"status_id"`
+
+		// The time the status was posted
+		CreateAt DateTime `db:"create_at"`
+
+		// How many favourites and reblogs the status received
+		Interactions uint64
+	}
+
+	// A trending status computed by the trends job
+	StatusTrend struct {
+		// The internal ID of the status
+		StatusID uint64 `db:"status_id"`
+
+		// How much the status is trending, higher first
+		Score float64
+	}
+)
+
+// Rank the tags by how many more accounts used them in the latest window than in the earlier ones on average.
+// Tags used no more than usual are not trending.
+func TagTrends(activities []*TagActivity, now time.Time) []*TagTrend {
+	trendByTagID := make(map[uint64]*TagTrend)
+	var trends []*TagTrend
+	for _, activity := range activities {
+		if activity.DaysAgo < 0 || activity.DaysAgo >= TrendHistoryDays {
+			continue
+		}
+		trend, ok := trendByTagID[activity.TagID]
+		if !ok {
+			trend = &TagTrend{TagID: activity.TagID, History: make(TagHistories, TrendHistoryDays)}
+			for i := range trend.History {
+				trend.History[i] = &TagHistory{Day: DateTime{now.Add(-time.Duration(i+1) * TrendWindow)}}
+			}
+			trendByTagID[activity.TagID] = trend
+			trends = append(trends, trend)
+		}
+		trend.History[activity.DaysAgo].Uses += activity.Uses
+		trend.History[activity.DaysAgo].Accounts += activity.Accounts
+	}
+
+	result := make([]*TagTrend, 0, len(trends))
+	for _, trend := range trends {
+		var earlier uint64
+		for _, history := range trend.History[1:] {
+			earlier += history.Accounts
+		}
+		trend.Score = float64(trend.History[0].Accounts) - float64(earlier)/float64(TrendHistoryDays-1)
+		if trend.Score > 0 {
+			result = append(result, trend)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Score > result[j].Score })
+	return result
+}
+
+// Rank the statuses by favourites and reblogs in the latest window, decaying with the age of the status
+// so that new statuses gaining attention quickly rank above old ones.
+func StatusTrends(activities []*StatusActivity, now time.Time) []*StatusTrend {
+	trends := make([]*StatusTrend, 0, len(activities))
+	for _, activity := range activities {
+		age := now.Sub(activity.CreateAt.Time)
+		if activity.Interactions == 0 || age > MaxTrendingStatusAge {
+			continue
+		}
+		if age < 0 {
+			age = 0
+		}
+		score := float64(activity.Interactions) / math.Pow(age.Hours()+2, 1.5)
+		trends = append(trends, &StatusTrend{StatusID: activity.StatusID, Score: score})
+	}
+	sort.SliceStable(trends, func(i, j int) bool { return trends[i].Score > trends[j].Score })
+	return trends
+}
+
+// database/sql/driver/Valuer
+func (h TagHistories) Value() (driver.Value, error) {
+	b, err := json.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// database/sql/Scanner
+func (h *TagHistories) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, h)
+	case string:
+		return json.Unmarshal([]byte(v), h)
+	}
+	return fmt.Errorf("cannot scan %T into TagHistories", value)
+}
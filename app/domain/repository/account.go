@@ -8,5 +8,9 @@ import (
 
 type Account interface {
 	Retrieve(ctx context.Context, username string) (*object.Account, error)
+	RetrieveByID(ctx context.Context, id object.AccountID) (*object.Account, error)
+	RetrieveByIDs(ctx context.Context, ids []object.AccountID) ([]*object.Account, error)
+	RetrieveByUsernames(ctx context.Context, usernames []string) ([]*object.Account, error)
 	Create(ctx context.Context, account *object.Account) error
+	Update(ctx context.Context, account *object.Account) error
 }
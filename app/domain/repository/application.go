@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Application interface {
+	Create(ctx context.Context, app *object.Application) error
+	RetrieveByClientID(ctx context.Context, clientID string) (*object.Application, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Attachment interface {
+	Create(ctx context.Context, attachment *object.Attachment) error
+	Retrieve(ctx context.Context, id uint64) (*object.Attachment, error)
+	RetrieveByIDs(ctx context.Context, ids []uint64) ([]*object.Attachment, error)
+	RetrieveByStatusIDs(ctx context.Context, statusIDs []uint64) ([]*object.Attachment, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type AuthorizationCode interface {
+	Create(ctx context.Context, code *object.AuthorizationCode) error
+	// Retrieve the code and delete it so that it can be exchanged only once.
+	// The code is kept if verify returns an error, which is returned as is.
+	Consume(ctx context.Context, code string, verify func(*object.AuthorizationCode) error) (*object.AuthorizationCode, error)
+}
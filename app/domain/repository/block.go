@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Block interface {
+	// Block targetID, removing the follows and follow requests between the two accounts.
+	// Blocking again is not an error.
+	Create(ctx context.Context, accountID object.AccountID, targetID object.AccountID) error
+	// Unblocking an account which is not blocked is not an error
+	Delete(ctx context.Context, accountID object.AccountID, targetID object.AccountID) error
+	// Returns the accounts blocked by accountID, newest first
+	RetrieveByAccountID(ctx context.Context, accountID object.AccountID, max_id, since_id, limit *uint64) ([]*object.Block, error)
+	// Check if either account is blocking the other
+	IsBlockedBetween(ctx context.Context, accountID object.AccountID, targetID object.AccountID) (bool, error)
+	// Returns which of targetIDs accountID is blocking
+	BlockedAccountIDs(ctx context.Context, accountID object.AccountID, targetIDs []object.AccountID) ([]object.AccountID, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Favourite interface {
+	Create(ctx context.Context, accountID object.AccountID, statusID uint64) error
+	Delete(ctx context.Context, accountID object.AccountID, statusID uint64) error
+	RetrieveFavouritedBy(ctx context.Context, statusID uint64, limit *uint64) ([]object.Account, error)
+	// Returns which of statusIDs the account has favourited
+	FavouritedStatusIDs(ctx context.Context, accountID object.AccountID, statusIDs []uint64) ([]uint64, error)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type FollowRequest interface {
+	// Request accountID to follow targetID. Requesting again is not an error.
+	Create(ctx context.Context, accountID object.AccountID, targetID object.AccountID) error
+	Retrieve(ctx context.Context, id uint64) (*object.FollowRequest, error)
+	// Returns the requests waiting for targetID's approval, newest first
+	RetrieveByTargetID(ctx context.Context, targetID object.AccountID, max_id, since_id, limit *uint64) ([]*object.FollowRequest, error)
+	// Remove the request and create the relationship in one transaction
+	Authorize(ctx context.Context, request *object.FollowRequest) error
+	Reject(ctx context.Context, id uint64) error
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type IdempotencyKey interface {
+	// Reserve the key for a request received at now.
+	// Returns false when the key is already used by a request which is in flight or completed within the lifetime.
+	Reserve(ctx context.Context, accountID object.AccountID, key string, now time.Time) (bool, error)
+	Retrieve(ctx context.Context, accountID object.AccountID, key string) (*object.IdempotencyKey, error)
+	// Store the response to replay for the key
+	Complete(ctx context.Context, accountID object.AccountID, key string, response string) error
+	// Release the key so that the request can be retried
+	Release(ctx context.Context, accountID object.AccountID, key string) error
+	// Delete the keys whose lifetime has passed at now and returns how many were deleted
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
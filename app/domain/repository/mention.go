@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Mention interface {
+	// Returns the mentions of the statuses in the order they appear
+	RetrieveByStatusIDs(ctx context.Context, statusIDs []uint64) ([]*object.Mention, error)
+	// Returns which of statusIDs mention the account
+	MentionedStatusIDs(ctx context.Context, accountID object.AccountID, statusIDs []uint64) ([]uint64, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Mute interface {
+	// Save the mute. Muting again replaces the options and the expiry.
+	Create(ctx context.Context, mute *object.Mute) error
+	// Unmuting an account which is not muted is not an error
+	Delete(ctx context.Context, accountID object.AccountID, targetID object.AccountID) error
+	// Returns the accounts muted by accountID and not expired at now, newest first
+	RetrieveByAccountID(ctx context.Context, accountID object.AccountID, now time.Time, max_id, since_id, limit *uint64) ([]*object.Mute, error)
+	// Delete the mutes expired at now and returns how many were deleted
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
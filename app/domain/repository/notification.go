@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+// Notifications are created by the repositories of follows, statuses and favourites
+type Notification interface {
+	Retrieve(ctx context.Context, id uint64) (*object.Notification, error)
+	// Returns the notifications of accountID selected by filter, newest first.
+	// Notifications from accounts blocked, or muted with notifications, by accountID are left out.
+	RetrieveByAccountID(ctx context.Context, accountID object.AccountID, filter object.NotificationFilter, max_id, since_id, limit *uint64) ([]*object.Notification, error)
+	// Delete every notification of accountID
+	Clear(ctx context.Context, accountID object.AccountID) error
+	// Delete the notification of accountID. Returns sql.ErrNoRows if there is no such notification.
+	Dismiss(ctx context.Context, accountID object.AccountID, id uint64) error
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+var (
+	// Returned by Poll.Vote when the poll no longer accepts votes
+	ErrPollExpired = errors.New("poll has already ended")
+
+	// Returned by Poll.Vote when the account has already voted on the poll
+	ErrAlreadyVoted = errors.New("already voted on the poll")
+)
+
+type Poll interface {
+	// Returns the poll with its options
+	Retrieve(ctx context.Context, id uint64) (*object.Poll, error)
+	RetrieveByIDs(ctx context.Context, ids []uint64) ([]*object.Poll, error)
+	// Record the account's choices and update the tallies in one transaction
+	Vote(ctx context.Context, id uint64, accountID object.AccountID, choices []int, now time.Time) error
+	// Returns the choices the account voted for, keyed by poll ID
+	OwnVotes(ctx context.Context, accountID object.AccountID, pollIDs []uint64) (map[uint64][]int, error)
+	// Close the polls whose deadline has passed and returns how many were closed
+	CloseExpired(ctx context.Context, now time.Time) (int64, error)
+}
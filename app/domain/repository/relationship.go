@@ -8,8 +8,12 @@ import (
 
 type Relationship interface {
 	Create(ctx context.Context, followingID object.AccountID, followerID object.AccountID) error
+	// Unfollow, withdrawing the follow request as well if it is still pending
 	Delete(ctx context.Context, followingID object.AccountID, followerID object.AccountID) error
 	Retrieve(ctx context.Context, accountID object.AccountID) ([]object.Relationship, error)
+	RetrieveByUsernames(ctx context.Context, accountID object.AccountID, usernames []string) ([]*object.AccountRelationship, error)
+	// Pending follow requests to locked accounts are not relationships yet
+	IsFollowing(ctx context.Context, followingID object.AccountID, followerID object.AccountID) (bool, error)
 	RetrieveFollowing(ctx context.Context, accountID object.AccountID, limit *uint64) ([]object.Account, error)
 	RetrieveFollowers(ctx context.Context, accountID object.AccountID, only_media, max_id, since_id, limit *uint64) ([]object.Account, error)
 	CountFollowing(ctx context.Context, accountID object.AccountID) (uint64, error)
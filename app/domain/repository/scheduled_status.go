@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type ScheduledStatus interface {
+	// Save the scheduled status and reserve its media so that they are not posted elsewhere
+	Create(ctx context.Context, scheduled *object.ScheduledStatus) error
+	Retrieve(ctx context.Context, id uint64) (*object.ScheduledStatus, error)
+	// Returns the account's scheduled statuses, earliest first
+	RetrieveByAccountID(ctx context.Context, accountID object.AccountID, limit *uint64) ([]*object.ScheduledStatus, error)
+	// Change the time to publish, cancelling a publication in progress and clearing a failure
+	Reschedule(ctx context.Context, id uint64, scheduledAt time.Time) error
+	Delete(ctx context.Context, id uint64) error
+
+	// Lease up to limit statuses due at now to the holder of token until leaseExpiresAt.
+	// Statuses leased by another scheduler are skipped until their lease expires, and failed statuses are skipped.
+	Claim(ctx context.Context, token string, now time.Time, leaseExpiresAt time.Time, limit int) ([]*object.ScheduledStatus, error)
+	// Post status and remove the scheduled status in one transaction.
+	// Returns false without posting when the lease is no longer held at now.
+	Publish(ctx context.Context, scheduled *object.ScheduledStatus, status *object.Status, now time.Time) (bool, error)
+	// Record why the status cannot be published and release the lease so that it is no longer claimed.
+	// Returns false without recording when the lease is no longer held at now.
+	Fail(ctx context.Context, scheduled *object.ScheduledStatus, failure string, now time.Time) (bool, error)
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+// Search over accounts, statuses and tags.
+// dao implements it with MySQL FULLTEXT indexes and app/search with an index held in the process.
+type Search interface {
+	// Returns accounts matching query: the exact username first, then usernames starting with query,
+	// display names containing it and last the accounts matching fuzzily, e.g. with a typo
+	Accounts(ctx context.Context, query string, offset, limit *uint64) ([]*object.Account, error)
+	// Returns public statuses whose text matches query, most relevant first.
+	// Statuses of accounts viewerID is blocking or muting are excluded. viewerID may be nil.
+	Statuses(ctx context.Context, viewerID *object.AccountID, query string, offset, limit *uint64) ([]*object.Status, error)
+	// Returns tags whose name starts with query, exact match first
+	Tags(ctx context.Context, query string, offset, limit *uint64) ([]*object.Tag, error)
+}
+
+// Reads what a search index held outside the database is built from
+type SearchSource interface {
+	// Returns up to limit accounts with ID greater than afterID, in ID order
+	AccountsAfter(ctx context.Context, afterID object.AccountID, limit uint64) ([]*object.Account, error)
+	// Returns up to limit public statuses which are not reblogs with ID greater than afterID, in ID order
+	StatusesAfter(ctx context.Context, afterID uint64, limit uint64) ([]*object.Status, error)
+	// Returns up to limit tags with ID greater than afterID, in ID order
+	TagsAfter(ctx context.Context, afterID uint64, limit uint64) ([]*object.Tag, error)
+	// Returns the accounts viewerID is blocking or muting
+	HiddenAccountIDs(ctx context.Context, viewerID object.AccountID) ([]object.AccountID, error)
+}
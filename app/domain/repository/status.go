@@ -2,15 +2,36 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"yatter-backend-go/app/domain/object"
 )
 
+var (
+	// Returned by Status.Create when the account has already reblogged the status
+	ErrAlreadyReblogged = errors.New("already reblogged the status")
+)
+
 type Status interface {
 	Create(ctx context.Context, status *object.Status) error
 	Retrieve(ctx context.Context, id uint64) (*object.Status, error)
+	RetrieveByIDs(ctx context.Context, ids []uint64) ([]*object.Status, error)
 	Delete(ctx context.Context, id uint64) error
+	// Replace the content, keeping the previous revision in the history
+	Update(ctx context.Context, status *object.Status) error
+	// Returns the previous revisions, oldest first
+	RetrieveEdits(ctx context.Context, statusID uint64) ([]*object.StatusEdit, error)
+	Context(ctx context.Context, status *object.Status) (ancestors []*object.Status, descendants []*object.Status, err error)
+
+	// Returns the account's reblog of the status
+	RetrieveReblog(ctx context.Context, accountID object.AccountID, statusID uint64) (*object.Status, error)
+	DeleteReblog(ctx context.Context, accountID object.AccountID, statusID uint64) error
+	// Returns which of statusIDs the account has reblogged
+	RebloggedStatusIDs(ctx context.Context, accountID object.AccountID, statusIDs []uint64) ([]uint64, error)
 
-	PublicTimeline(ctx context.Context, only_media, max_id, since_id, limit *uint64) ([]*object.Status, error)
-	HomeTimeline(ctx context.Context, accountID object.AccountID, only_media, max_id, since_id, limit *uint64) ([]object.Status, error)
+	// Statuses of accounts blocked or muted by viewerID are left out unless viewerID is nil
+	PublicTimeline(ctx context.Context, viewerID *object.AccountID, only_media, max_id, since_id, limit *uint64) ([]*object.Status, error)
+	HomeTimeline(ctx context.Context, accountID object.AccountID, only_media, max_id, since_id, limit *uint64) ([]*object.Status, error)
+	// Returns the public statuses selected by the tags
+	TagTimeline(ctx context.Context, viewerID *object.AccountID, filter object.TagFilter, only_media, max_id, since_id, limit *uint64) ([]*object.Status, error)
 }
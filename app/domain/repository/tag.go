@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Tag interface {
+	// Returns the tags used in the statuses with StatusID set
+	RetrieveByStatusIDs(ctx context.Context, statusIDs []uint64) ([]*object.Tag, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Token interface {
+	Create(ctx context.Context, token *object.Token) error
+	Retrieve(ctx context.Context, accessToken string) (*object.Token, error)
+	Delete(ctx context.Context, accessToken string) error
+}
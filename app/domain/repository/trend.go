@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Trend interface {
+	// Returns the usage of tags in public statuses for each window of object.TrendWindow before now
+	TagActivity(ctx context.Context, now time.Time, windows int) ([]*object.TagActivity, error)
+	// Returns the favourites and reblogs public statuses posted since postedSince received since since,
+	// excluding those by the authors of the statuses
+	StatusActivity(ctx context.Context, since, postedSince time.Time) ([]*object.StatusActivity, error)
+
+	// Replace all trending tags with the new ones in one transaction
+	ReplaceTags(ctx context.Context, trends []*object.TagTrend) error
+	// Replace all trending statuses with the new ones in one transaction
+	ReplaceStatuses(ctx context.Context, trends []*object.StatusTrend) error
+
+	// Returns the trending tags with their history, most trending first
+	Tags(ctx context.Context, limit *uint64) ([]*object.Tag, error)
+	// Returns the trending statuses, most trending first.
+	// If viewerID is not nil, statuses by accounts the viewer blocks or mutes are excluded
+	Statuses(ctx context.Context, viewerID *object.AccountID, limit *uint64) ([]*object.Status, error)
+}
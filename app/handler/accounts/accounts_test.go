@@ -5,12 +5,17 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"yatter-backend-go/app/app"
 	"yatter-backend-go/app/dao"
 	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/testutil"
+	"yatter-backend-go/app/storage"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-chi/chi"
@@ -166,6 +171,152 @@ func TestFindUserHandler(t *testing.T) {
 	}
 }
 
+func TestUpdateCredentialsHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	h.app.Storage = storage.NewLocal(t.TempDir(), "/media")
+	defer db.Close()
+
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 64)...)
+
+	tests := []struct {
+		name     string
+		fields   map[string]string
+		files    map[string][]byte
+		isAuth   bool
+		mockFunc func()
+		wantCode int
+	}{
+		{
+			name:   "successfully update display name and note",
+			fields: map[string]string{"display_name": "Test User", "note": "hello"},
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectExec("update account set display_name = \\?, avatar = \\?, header = \\?, note = \\?, locked = \\? where id = \\?").
+					WithArgs("Test User", nil, nil, "hello", false, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("select \\* from account where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "display_name", "note"}).AddRow(1, "testuser", "Test User", "hello"))
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "successfully update avatar",
+			files:  map[string][]byte{"avatar": png},
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectExec("update account set display_name = \\?, avatar = \\?, header = \\?, note = \\?, locked = \\? where id = \\?").
+					WithArgs(nil, sqlmock.AnyArg(), nil, nil, false, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("select \\* from account where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "avatar"}).AddRow(1, "testuser", "/media/accounts/avatars/1/a.png"))
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "successfully lock account",
+			fields: map[string]string{"locked": "true"},
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectExec("update account set display_name = \\?, avatar = \\?, header = \\?, note = \\?, locked = \\? where id = \\?").
+					WithArgs(nil, nil, nil, nil, true, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("select \\* from account where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "locked"}).AddRow(1, "testuser", true))
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "invalid locked",
+			fields: map[string]string{"locked": "maybe"},
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:   "too long display name",
+			fields: map[string]string{"display_name": strings.Repeat("a", object.MaxDisplayNameLength+1)},
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:   "too large request",
+			files:  map[string][]byte{"header": append(png, make([]byte, 2*maxImageSize+maxMultipartMemory)...)},
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:   "avatar is not an image",
+			files:  map[string][]byte{"avatar": []byte("plain text")},
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unauthorized",
+			fields:   map[string]string{"note": "hello"},
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := new(bytes.Buffer)
+			mw := multipart.NewWriter(body)
+			for k, v := range tt.fields {
+				if err := mw.WriteField(k, v); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for k, v := range tt.files {
+				fw, err := mw.CreateFormFile(k, k)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err := fw.Write(v); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := mw.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			r, err := http.NewRequest(http.MethodPatch, "/v1/accounts/update_credentials", body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.Header.Set("Content-Type", mw.FormDataContentType())
+			if tt.isAuth {
+				testutil.SetAuth(r)
+			}
+			if tt.mockFunc != nil {
+				tt.mockFunc()
+			}
+
+			w := httptest.NewRecorder()
+			auth.Middleware(h.app)(http.HandlerFunc(h.UpdateCredentials)).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
+
 func newMockHandler(db *sql.DB) *handler {
 	return &handler{
 		app: &app.App{
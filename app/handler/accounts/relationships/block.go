@@ -0,0 +1,34 @@
+package relationships
+
+import (
+	"net/http"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Handler request for `POST /v1/accounts/{username}/block`
+func (h *handler) Block(w http.ResponseWriter, r *http.Request) {
+	account, target, ok := h.retrieveTarget(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.app.Dao.Block().Create(r.Context(), account.ID, target.ID); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	h.writeRelationship(w, r, account, target)
+}
+
+// Handler request for `POST /v1/accounts/{username}/unblock`
+func (h *handler) Unblock(w http.ResponseWriter, r *http.Request) {
+	account, target, ok := h.retrieveTarget(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.app.Dao.Block().Delete(r.Context(), account.ID, target.ID); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	h.writeRelationship(w, r, account, target)
+}
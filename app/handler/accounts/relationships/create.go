@@ -47,7 +47,38 @@ func (h *handler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = h.app.Dao.Relationship().Create(ctx, followingAccount.ID, followerAccount.ID); err != nil {
+	// どちらかがブロックしている間はフォローできない
+	blocked, err := h.app.Dao.Block().IsBlockedBetween(ctx, followingAccount.ID, followerAccount.ID)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if blocked {
+		httperror.Error(w, http.StatusForbidden)
+		return
+	}
+
+	// 鍵アカウントへのフォローは承認されるまでリクエストとして保留する
+	if followerAccount.Locked {
+		following, err := h.app.Dao.Relationship().IsFollowing(ctx, followingAccount.ID, followerAccount.ID)
+		if err != nil {
+			httperror.InternalServerError(w, err)
+			return
+		}
+		if !following {
+			if err := h.app.Dao.FollowRequest().Create(ctx, followingAccount.ID, followerAccount.ID); err != nil {
+				httperror.InternalServerError(w, err)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusAccepted)
+			if err := json.NewEncoder(w).Encode(relationship); err != nil {
+				httperror.InternalServerError(w, err)
+				return
+			}
+			return
+		}
+	} else if err = h.app.Dao.Relationship().Create(ctx, followingAccount.ID, followerAccount.ID); err != nil {
 		httperror.InternalServerError(w, err)
 		return
 	}
@@ -2,11 +2,17 @@ package relationships
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
+	"yatter-backend-go/app/domain/object"
 	"yatter-backend-go/app/handler/auth"
 	"yatter-backend-go/app/handler/httperror"
 )
 
+// maxUsernames is the maximum number of accounts queried at once
+const maxUsernames = 80
+
 // Handler request for `GET /v1/accounts/relationships`
 func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -16,15 +22,52 @@ func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	relationships, err := h.app.Dao.Relationship().Retrieve(ctx, account.ID)
+	usernames := parseUsernames(r.URL.Query().Get("username"))
+	if len(usernames) == 0 {
+		httperror.BadRequest(w, fmt.Errorf("username is required"))
+		return
+	}
+	if len(usernames) > maxUsernames {
+		httperror.BadRequest(w, fmt.Errorf("too many usernames (max %d)", maxUsernames))
+		return
+	}
+
+	relationships, err := h.app.Dao.Relationship().RetrieveByUsernames(ctx, account.ID, usernames)
 	if err != nil {
 		httperror.InternalServerError(w, err)
 		return
 	}
 
+	// リクエストされた順に並べ替え、存在しないアカウントは除外する
+	byUsername := make(map[string]*object.AccountRelationship, len(relationships))
+	for _, relationship := range relationships {
+		byUsername[relationship.Username] = relationship
+	}
+	res := make([]*object.AccountRelationship, 0, len(relationships))
+	for _, username := range usernames {
+		if relationship, ok := byUsername[username]; ok {
+			res = append(res, relationship)
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(relationships); err != nil {
+	if err := json.NewEncoder(w).Encode(res); err != nil {
 		httperror.InternalServerError(w, err)
 		return
 	}
 }
+
+// parseUsernames splits a comma-separated username list, dropping blanks and duplicates
+func parseUsernames(s string) []string {
+	var usernames []string
+	seen := make(map[string]bool)
+	for _, username := range strings.Split(s, ",") {
+		username = strings.TrimSpace(username)
+		if username == "" || seen[username] {
+			continue
+		}
+		seen[username] = true
+		usernames = append(usernames, username)
+	}
+	return usernames
+}
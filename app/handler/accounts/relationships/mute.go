@@ -0,0 +1,63 @@
+package relationships
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"time"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+type MuteRequest struct {
+	// Whether notifications from the account are muted too (default true)
+	Notifications *bool `json:"notifications"`
+	// How many seconds the mute lasts, 0 or omitted to mute until unmuted
+	Duration uint64 `json:"duration"`
+}
+
+// Handler request for `POST /v1/accounts/{username}/mute`
+func (h *handler) Mute(w http.ResponseWriter, r *http.Request) {
+	account, target, ok := h.retrieveTarget(w, r)
+	if !ok {
+		return
+	}
+
+	// 本文は省略できる
+	var req MuteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if req.Duration > uint64(math.MaxInt64/int64(time.Second)) {
+		httperror.BadRequest(w, fmt.Errorf("duration is too long"))
+		return
+	}
+	hideNotifications := true
+	if req.Notifications != nil {
+		hideNotifications = *req.Notifications
+	}
+
+	mute := object.NewMute(account.ID, target.ID, hideNotifications, time.Duration(req.Duration)*time.Second, time.Now())
+	if err := h.app.Dao.Mute().Create(r.Context(), mute); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	h.writeRelationship(w, r, account, target)
+}
+
+// Handler request for `POST /v1/accounts/{username}/unmute`
+func (h *handler) Unmute(w http.ResponseWriter, r *http.Request) {
+	account, target, ok := h.retrieveTarget(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.app.Dao.Mute().Delete(r.Context(), account.ID, target.ID); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	h.writeRelationship(w, r, account, target)
+}
@@ -5,17 +5,22 @@ import (
 	"database/sql"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"yatter-backend-go/app/app"
 	"yatter-backend-go/app/dao"
 	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/testutil"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-chi/chi"
+	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 )
 
+const isBlockedQuery = "select count\\(\\*\\) from block where \\(account_id = \\? and target_account_id = \\?\\) or \\(account_id = \\? and target_account_id = \\?\\)"
+
 func TestCreate(t *testing.T) {
 	db, mock := dao.NewMockDB()
 	h := newMockHandler(db)
@@ -31,14 +36,46 @@ func TestCreate(t *testing.T) {
 		{
 			name: "successfully create account",
 			mockFunc: func() {
-				// auth.Middlewareで実行されるクエリ
+				testutil.ExpectAuth(mock, 1, "testuser")
+				// followする人のクエリ
 				mock.ExpectQuery("select \\* from account where username = \\?").
 					WithArgs("testuser").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
-				// followする人のクエリ
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "testuser2"))
+				mock.ExpectQuery(isBlockedQuery).
+					WithArgs(1, 2, 2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec("insert into relationship \\(following_id, follower_id\\) values \\(\\?, \\?\\)").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("update account set following_count = following_count \\+ 1 where id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("update account set followers_count = followers_count \\+ 1 where id = \\?").
+					WithArgs(2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("insert into notification \\(account_id, from_account_id, type, status_id, status_key\\) values").
+					WithArgs(2, 1, "follow", nil, 0).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectCommit()
+			},
+			isAuth:       true,
+			urlParamFunc: func(r *http.Request) *http.Request { return setChiURLParam(r, "username", "testuser") },
+			wantCode:     http.StatusOK,
+		},
+		{
+			name: "follow again after unfollowing",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
 				mock.ExpectQuery("select \\* from account where username = \\?").
 					WithArgs("testuser").
 					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "testuser2"))
+				mock.ExpectQuery(isBlockedQuery).
+					WithArgs(1, 2, 2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 
 				mock.ExpectBegin()
 
@@ -51,6 +88,10 @@ func TestCreate(t *testing.T) {
 				mock.ExpectExec("update account set followers_count = followers_count \\+ 1 where id = \\?").
 					WithArgs(2).
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				// 前回のフォローで通知済み
+				mock.ExpectExec("insert into notification \\(account_id, from_account_id, type, status_id, status_key\\) values").
+					WithArgs(2, 1, "follow", nil, 0).
+					WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
 
 				mock.ExpectCommit()
 			},
@@ -58,6 +99,92 @@ func TestCreate(t *testing.T) {
 			urlParamFunc: func(r *http.Request) *http.Request { return setChiURLParam(r, "username", "testuser") },
 			wantCode:     http.StatusOK,
 		},
+		{
+			name: "notification for deleted account",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from account where username = \\?").
+					WithArgs("testuser").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "testuser2"))
+				mock.ExpectQuery(isBlockedQuery).
+					WithArgs(1, 2, 2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec("insert into relationship \\(following_id, follower_id\\) values \\(\\?, \\?\\)").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("update account set following_count = following_count \\+ 1 where id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("update account set followers_count = followers_count \\+ 1 where id = \\?").
+					WithArgs(2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("insert into notification \\(account_id, from_account_id, type, status_id, status_key\\) values").
+					WithArgs(2, 1, "follow", nil, 0).
+					WillReturnError(&mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails"})
+
+				mock.ExpectRollback()
+			},
+			isAuth:       true,
+			urlParamFunc: func(r *http.Request) *http.Request { return setChiURLParam(r, "username", "testuser") },
+			wantCode:     http.StatusInternalServerError,
+		},
+		{
+			name: "request to follow locked account",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from account where username = \\?").
+					WithArgs("testuser").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "locked"}).AddRow(2, "testuser2", true))
+				mock.ExpectQuery(isBlockedQuery).
+					WithArgs(1, 2, 2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectQuery("select count\\(\\*\\) from relationship where following_id = \\? and follower_id = \\?").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectExec("insert ignore into follow_request \\(account_id, target_account_id\\) values \\(\\?, \\?\\)").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			isAuth:       true,
+			urlParamFunc: func(r *http.Request) *http.Request { return setChiURLParam(r, "username", "testuser") },
+			wantCode:     http.StatusAccepted,
+		},
+		{
+			name: "already following locked account",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from account where username = \\?").
+					WithArgs("testuser").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "locked"}).AddRow(2, "testuser2", true))
+				mock.ExpectQuery(isBlockedQuery).
+					WithArgs(1, 2, 2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectQuery("select count\\(\\*\\) from relationship where following_id = \\? and follower_id = \\?").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			isAuth:       true,
+			urlParamFunc: func(r *http.Request) *http.Request { return setChiURLParam(r, "username", "testuser") },
+			wantCode:     http.StatusOK,
+		},
+		{
+			name: "blocked",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from account where username = \\?").
+					WithArgs("testuser").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "testuser2"))
+				mock.ExpectQuery(isBlockedQuery).
+					WithArgs(1, 2, 2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			isAuth:       true,
+			urlParamFunc: func(r *http.Request) *http.Request { return setChiURLParam(r, "username", "testuser") },
+			wantCode:     http.StatusForbidden,
+		},
 		{
 			name:     "Unauthorized",
 			wantCode: http.StatusUnauthorized,
@@ -65,9 +192,7 @@ func TestCreate(t *testing.T) {
 		{
 			name: "same following and follower",
 			mockFunc: func() {
-				mock.ExpectQuery("select \\* from account where username = \\?").
-					WithArgs("testuser").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
+				testutil.ExpectAuth(mock, 1, "testuser")
 				mock.ExpectQuery("select \\* from account where username = \\?").
 					WithArgs("testuser").
 					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
@@ -89,7 +214,7 @@ func TestCreate(t *testing.T) {
 				r = tt.urlParamFunc(r)
 			}
 			if tt.isAuth {
-				r.Header.Set("Authentication", "username testuser")
+				testutil.SetAuth(r)
 			}
 			if tt.mockFunc != nil {
 				tt.mockFunc()
@@ -120,9 +245,7 @@ func TestDelete(t *testing.T) {
 		{
 			name: "successfully delete account",
 			mockFunc: func() {
-				mock.ExpectQuery("select \\* from account where username = \\?").
-					WithArgs("followingUser").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "followingUser"))
+				testutil.ExpectAuth(mock, 1, "followingUser")
 				mock.ExpectQuery("select \\* from account where username = \\?").
 					WithArgs("unfollowUser").
 					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "unfollowUser"))
@@ -138,6 +261,32 @@ func TestDelete(t *testing.T) {
 				mock.ExpectExec("update account set followers_count = followers_count - 1 where id = \\?").
 					WithArgs(2).
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("delete from follow_request where account_id = \\? and target_account_id = \\?").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectCommit()
+			},
+			isAuth:       true,
+			urlParamFunc: func(r *http.Request) *http.Request { return setChiURLParam(r, "username", "unfollowUser") },
+			wantCode:     http.StatusOK,
+		},
+		{
+			name: "withdraw pending follow request",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "followingUser")
+				mock.ExpectQuery("select \\* from account where username = \\?").
+					WithArgs("unfollowUser").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "unfollowUser"))
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec("delete from relationship where following_id = \\? and follower_id = \\?").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("delete from follow_request where account_id = \\? and target_account_id = \\?").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
 
 				mock.ExpectCommit()
 			},
@@ -152,9 +301,7 @@ func TestDelete(t *testing.T) {
 		{
 			name: "user not found",
 			mockFunc: func() {
-				mock.ExpectQuery("select \\* from account where username = \\?").
-					WithArgs("followingUser").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "followingUser"))
+				testutil.ExpectAuth(mock, 1, "followingUser")
 				mock.ExpectQuery("select \\* from account where username = \\?").
 					WithArgs("unfollowUser").
 					WillReturnError(sql.ErrNoRows)
@@ -166,9 +313,7 @@ func TestDelete(t *testing.T) {
 		{
 			name: "relationship not found",
 			mockFunc: func() {
-				mock.ExpectQuery("select \\* from account where username = \\?").
-					WithArgs("followingUser").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "followingUser"))
+				testutil.ExpectAuth(mock, 1, "followingUser")
 				mock.ExpectQuery("select \\* from account where username = \\?").
 					WithArgs("unfollowUser").
 					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "unfollowUser"))
@@ -178,6 +323,10 @@ func TestDelete(t *testing.T) {
 				mock.ExpectExec("delete from relationship where following_id = \\? and follower_id = \\?").
 					WithArgs(1, 2).
 					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("delete from follow_request where account_id = \\? and target_account_id = \\?").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
 			},
 			isAuth:       true,
 			urlParamFunc: func(r *http.Request) *http.Request { return setChiURLParam(r, "username", "unfollowUser") },
@@ -196,7 +345,7 @@ func TestDelete(t *testing.T) {
 				r = tt.urlParamFunc(r)
 			}
 			if tt.isAuth {
-				r.Header.Set("Authentication", "username followingUser")
+				testutil.SetAuth(r)
 			}
 			if tt.mockFunc != nil {
 				tt.mockFunc()
@@ -207,61 +356,291 @@ func TestDelete(t *testing.T) {
 			handlerMiddleware.ServeHTTP(w, r)
 
 			assert.Equal(t, tt.wantCode, w.Code)
+			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 
 }
 
-func TestFetchList(t *testing.T) {
+func TestBlock(t *testing.T) {
 	db, mock := dao.NewMockDB()
 	h := newMockHandler(db)
 	defer db.Close()
 
+	const relationshipQuery = "select account.id, account.username, exists .* as muting_notifications from account where account.username in \\(\\?\\)"
+	columns := []string{"id", "username", "following", "followed_by", "requested", "blocking", "muting", "muting_notifications"}
+
 	tests := []struct {
 		name     string
+		handler  http.HandlerFunc
 		mockFunc func()
-		isAuth   bool
 		wantCode int
+		wantBody string
 	}{
 		{
-			name: "successfully fetch list",
+			name:    "successfully block",
+			handler: h.Block,
 			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
 				mock.ExpectQuery("select \\* from account where username = \\?").
-					WithArgs("testuser").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
-				mock.ExpectQuery("select \\* from relationship where following_id = \\? or follower_id = \\?").
-					WithArgs(1, 1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "following_id", "follower_id"}).AddRow(1, 1, 2))
+					WithArgs("testuser2").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "testuser2"))
+				mock.ExpectBegin()
+				mock.ExpectExec("insert ignore into block \\(account_id, target_account_id\\) values \\(\\?, \\?\\)").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				// 自分からのフォローは解除し、フォロー数を減らす
+				mock.ExpectExec("delete from relationship where following_id = \\? and follower_id = \\?").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("update account set following_count = following_count - 1 where id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("update account set followers_count = followers_count - 1 where id = \\?").
+					WithArgs(2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				// 相手からのフォローはないのでフォロー数はそのまま
+				mock.ExpectExec("delete from relationship where following_id = \\? and follower_id = \\?").
+					WithArgs(2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("delete from follow_request where \\(account_id = \\? and target_account_id = \\?\\) or \\(account_id = \\? and target_account_id = \\?\\)").
+					WithArgs(1, 2, 2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				mock.ExpectQuery(relationshipQuery).
+					WithArgs(1, 1, 1, 1, 1, sqlmock.AnyArg(), 1, sqlmock.AnyArg(), "testuser2").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "testuser2", false, false, false, true, false, false))
 			},
-			isAuth:   true,
 			wantCode: http.StatusOK,
+			wantBody: `{"id":2,"following":false,"followed_by":false,"requested":false,"blocking":true,"muting":false,"muting_notifications":false}`,
 		},
 		{
-			name: "empty list",
+			name:    "successfully unblock",
+			handler: h.Unblock,
 			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
 				mock.ExpectQuery("select \\* from account where username = \\?").
-					WithArgs("testuser").
+					WithArgs("testuser2").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "testuser2"))
+				mock.ExpectExec("delete from block where account_id = \\? and target_account_id = \\?").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(relationshipQuery).
+					WithArgs(1, 1, 1, 1, 1, sqlmock.AnyArg(), 1, sqlmock.AnyArg(), "testuser2").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "testuser2", false, false, false, false, false, false))
+			},
+			wantCode: http.StatusOK,
+			wantBody: `{"id":2,"following":false,"followed_by":false,"requested":false,"blocking":false,"muting":false,"muting_notifications":false}`,
+		},
+		{
+			name:    "block myself",
+			handler: h.Block,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from account where username = \\?").
+					WithArgs("testuser2").
 					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
-				mock.ExpectQuery("select \\* from relationship where following_id = \\? or follower_id = \\?").
-					WithArgs(1, 1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "following_id", "follower_id"})) // empty
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:    "account not found",
+			handler: h.Block,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from account where username = \\?").
+					WithArgs("testuser2").
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodPost, "/v1/accounts/testuser2/block", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r = setChiURLParam(r, "username", "testuser2")
+			testutil.SetAuth(r)
+			tt.mockFunc()
+			auth.Middleware(h.app)(tt.handler).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMute(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	const relationshipQuery = "select account.id, account.username, exists .* as muting_notifications from account where account.username in \\(\\?\\)"
+	columns := []string{"id", "username", "following", "followed_by", "requested", "blocking", "muting", "muting_notifications"}
+	expectTarget := func() {
+		testutil.ExpectAuth(mock, 1, "testuser")
+		mock.ExpectQuery("select \\* from account where username = \\?").
+			WithArgs("testuser2").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "testuser2"))
+	}
+
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		body     string
+		mockFunc func()
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:    "successfully mute",
+			handler: h.Mute,
+			mockFunc: func() {
+				expectTarget()
+				mock.ExpectExec("insert into mute \\(account_id, target_account_id, hide_notifications, expires_at\\) values \\(\\?, \\?, \\?, \\?\\) on duplicate key update").
+					WithArgs(1, 2, true, nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(relationshipQuery).
+					WithArgs(1, 1, 1, 1, 1, sqlmock.AnyArg(), 1, sqlmock.AnyArg(), "testuser2").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "testuser2", true, false, false, false, true, true))
+			},
+			wantCode: http.StatusOK,
+			wantBody: `{"id":2,"following":true,"followed_by":false,"requested":false,"blocking":false,"muting":true,"muting_notifications":true}`,
+		},
+		{
+			name:    "mute for an hour without notifications",
+			handler: h.Mute,
+			body:    `{"notifications":false,"duration":3600}`,
+			mockFunc: func() {
+				expectTarget()
+				mock.ExpectExec("insert into mute").
+					WithArgs(1, 2, false, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(relationshipQuery).
+					WithArgs(1, 1, 1, 1, 1, sqlmock.AnyArg(), 1, sqlmock.AnyArg(), "testuser2").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "testuser2", false, false, false, false, true, false))
+			},
+			wantCode: http.StatusOK,
+			wantBody: `{"id":2,"following":false,"followed_by":false,"requested":false,"blocking":false,"muting":true,"muting_notifications":false}`,
+		},
+		{
+			name:    "invalid duration",
+			handler: h.Mute,
+			body:    `{"duration":-1}`,
+			mockFunc: func() {
+				expectTarget()
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:    "successfully unmute",
+			handler: h.Unmute,
+			mockFunc: func() {
+				expectTarget()
+				mock.ExpectExec("delete from mute where account_id = \\? and target_account_id = \\?").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(relationshipQuery).
+					WithArgs(1, 1, 1, 1, 1, sqlmock.AnyArg(), 1, sqlmock.AnyArg(), "testuser2").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "testuser2", true, false, false, false, false, false))
+			},
+			wantCode: http.StatusOK,
+			wantBody: `{"id":2,"following":true,"followed_by":false,"requested":false,"blocking":false,"muting":false,"muting_notifications":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodPost, "/v1/accounts/testuser2/mute", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			r = setChiURLParam(r, "username", "testuser2")
+			testutil.SetAuth(r)
+			tt.mockFunc()
+			auth.Middleware(h.app)(tt.handler).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFetchList(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	const relationshipsQuery = "select account.id, account.username, exists \\(select 1 from relationship where following_id = \\? and follower_id = account.id\\) as following, exists \\(select 1 from relationship where following_id = account.id and follower_id = \\?\\) as followed_by, exists \\(select 1 from follow_request where account_id = \\? and target_account_id = account.id\\) as requested, exists \\(select 1 from block where account_id = \\? and target_account_id = account.id\\) as blocking, exists .* as muting, exists .* as muting_notifications from account where account.username in"
+	columns := []string{"id", "username", "following", "followed_by", "requested", "blocking", "muting", "muting_notifications"}
+
+	tests := []struct {
+		name     string
+		query    string
+		mockFunc func()
+		isAuth   bool
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:  "successfully fetch list",
+			query: "?username=test3,test2,unknown,test3",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery(relationshipsQuery).
+					WithArgs(1, 1, 1, 1, 1, sqlmock.AnyArg(), 1, sqlmock.AnyArg(), "test3", "test2", "unknown").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "test2", true, false, false, false, false, false).AddRow(3, "test3", false, true, true, false, false, false))
+			},
+			isAuth:   true,
+			wantCode: http.StatusOK,
+			wantBody: `[{"id":3,"following":false,"followed_by":true,"requested":true,"blocking":false,"muting":false,"muting_notifications":false},{"id":2,"following":true,"followed_by":false,"requested":false,"blocking":false,"muting":false,"muting_notifications":false}]`,
+		},
+		{
+			name:  "empty list",
+			query: "?username=test2",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery(relationshipsQuery).
+					WithArgs(1, 1, 1, 1, 1, sqlmock.AnyArg(), 1, sqlmock.AnyArg(), "test2").
+					WillReturnRows(sqlmock.NewRows(columns)) // empty
 			},
 			isAuth:   true,
 			wantCode: http.StatusOK,
+			wantBody: `[]`,
+		},
+		{
+			name:  "missing username",
+			query: "?username=,",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+			},
+			isAuth:   true,
+			wantCode: http.StatusBadRequest,
 		},
 		{
 			name:     "Unauthorized",
+			query:    "?username=test2",
 			wantCode: http.StatusUnauthorized,
 		},
 		{
-			name: "db error",
+			name:  "db error",
+			query: "?username=test2",
 			mockFunc: func() {
-				mock.ExpectQuery("select \\* from account where username = \\?").
-					WithArgs("testuser").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
-				mock.ExpectQuery("select \\* from relationship where following_id = \\? or follower_id = \\?").
-					WithArgs(1, 1).
-					WillReturnError(sql.ErrNoRows)
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery(relationshipsQuery).
+					WithArgs(1, 1, 1, 1, 1, sqlmock.AnyArg(), 1, sqlmock.AnyArg(), "test2").
+					WillReturnError(sql.ErrConnDone)
 			},
 			isAuth:   true,
 			wantCode: http.StatusInternalServerError,
@@ -271,12 +650,12 @@ func TestFetchList(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
-			r, err := http.NewRequest(http.MethodGet, "/v1/accounts/relationships", nil)
+			r, err := http.NewRequest(http.MethodGet, "/v1/accounts/relationships"+tt.query, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
 			if tt.isAuth {
-				r.Header.Set("Authentication", "username testuser")
+				testutil.SetAuth(r)
 			}
 			if tt.mockFunc != nil {
 				tt.mockFunc()
@@ -287,6 +666,10 @@ func TestFetchList(t *testing.T) {
 			handlerMiddleware.ServeHTTP(w, r)
 
 			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 
 	}
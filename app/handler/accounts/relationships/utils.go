@@ -1,6 +1,14 @@
 package relationships
 
-import "yatter-backend-go/app/app"
+import (
+	"encoding/json"
+	"net/http"
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
 
 type handler struct {
 	app *app.App
@@ -9,3 +17,47 @@ type handler struct {
 func NewHandler(app *app.App) *handler {
 	return &handler{app: app}
 }
+
+// Retrieve the caller and the account of path parameter `username`
+func (h *handler) retrieveTarget(w http.ResponseWriter, r *http.Request) (*object.Account, *object.Account, bool) {
+	account := auth.AccountOf(r)
+	if account == nil {
+		httperror.Error(w, http.StatusUnauthorized)
+		return nil, nil, false
+	}
+
+	username, err := request.UsernameOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return nil, nil, false
+	}
+	target, err := h.app.Dao.Account().Retrieve(r.Context(), username)
+	if err != nil {
+		httperror.NotFound(w, err)
+		return nil, nil, false
+	}
+	if target.ID == account.ID {
+		httperror.Error(w, http.StatusBadRequest)
+		return nil, nil, false
+	}
+	return account, target, true
+}
+
+// Write the relationship between the caller and target
+func (h *handler) writeRelationship(w http.ResponseWriter, r *http.Request, account, target *object.Account) {
+	relationships, err := h.app.Dao.Relationship().RetrieveByUsernames(r.Context(), account.ID, []string{target.Username})
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if len(relationships) == 0 {
+		httperror.NotFound(w, target.Username)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(relationships[0]); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
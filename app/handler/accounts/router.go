@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/domain/object"
 	"yatter-backend-go/app/handler/accounts/relationships"
 	"yatter-backend-go/app/handler/auth"
 
@@ -21,12 +22,17 @@ func NewRouter(app *app.App) http.Handler {
 	accoutnHandler := &handler{app: app}
 	relationshipHandler := relationships.NewHandler(app)
 	r.Post("/", accoutnHandler.Create)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeWriteAccounts)).Patch("/update_credentials", accoutnHandler.UpdateCredentials)
 	r.Get("/{username}", accoutnHandler.Get)
 
 	// Relationship
-	r.With(auth.Middleware(app)).Post("/{username}/follow", relationshipHandler.Create)
-	r.With(auth.Middleware(app)).Post("/{username}/unfollow", relationshipHandler.Delete)
-	r.With(auth.Middleware(app)).Get("/relationships", relationshipHandler.Get)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeWriteFollows)).Post("/{username}/follow", relationshipHandler.Create)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeWriteFollows)).Post("/{username}/unfollow", relationshipHandler.Delete)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeReadFollows)).Get("/relationships", relationshipHandler.Get)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeWriteBlocks)).Post("/{username}/block", relationshipHandler.Block)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeWriteBlocks)).Post("/{username}/unblock", relationshipHandler.Unblock)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeWriteMutes)).Post("/{username}/mute", relationshipHandler.Mute)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeWriteMutes)).Post("/{username}/unmute", relationshipHandler.Unmute)
 
 	r.Get("/{username}/following", relationshipHandler.GetFollowing)
 	r.Get("/{username}/followers", relationshipHandler.GetFollowers)
@@ -0,0 +1,116 @@
+package accounts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/upload"
+	"yatter-backend-go/app/storage"
+)
+
+const (
+	// Maximum size of avatar and header images
+	maxImageSize = 2 << 20
+
+	maxMultipartMemory = 8 << 20
+)
+
+// Handle request for `PATCH /v1/accounts/update_credentials`
+func (h *handler) UpdateCredentials(w http.ResponseWriter, r *http.Request) {
+	account := auth.AccountOf(r)
+	if account == nil {
+		httperror.Error(w, http.StatusUnauthorized)
+		return
+	}
+	ctx := r.Context()
+
+	// Room for both the avatar and the header
+	r.Body = http.MaxBytesReader(w, r.Body, 2*maxImageSize+maxMultipartMemory)
+	if err := r.ParseMultipartForm(maxMultipartMemory); err != nil && err != http.ErrNotMultipart {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	// Only the fields present in the request are changed
+	updated := *account
+	if values, ok := r.PostForm["display_name"]; ok {
+		if err := updated.SetDisplayName(values[0]); err != nil {
+			httperror.BadRequest(w, err)
+			return
+		}
+	}
+	if values, ok := r.PostForm["note"]; ok {
+		if err := updated.SetNote(values[0]); err != nil {
+			httperror.BadRequest(w, err)
+			return
+		}
+	}
+
+	if values, ok := r.PostForm["locked"]; ok {
+		locked, err := strconv.ParseBool(values[0])
+		if err != nil {
+			httperror.BadRequest(w, err)
+			return
+		}
+		updated.Locked = locked
+	}
+
+	if r.MultipartForm != nil {
+		for _, field := range []struct {
+			name   string
+			target **string
+		}{
+			{name: "avatar", target: &updated.Avatar},
+			{name: "header", target: &updated.Header},
+		} {
+			file, _, err := r.FormFile(field.name)
+			if err == http.ErrMissingFile {
+				continue
+			} else if err != nil {
+				httperror.BadRequest(w, err)
+				return
+			}
+
+			image, err := upload.Read(file, maxImageSize, upload.ImageTypes)
+			file.Close()
+			if err != nil {
+				httperror.BadRequest(w, err)
+				return
+			}
+
+			key, err := storage.NewKey(fmt.Sprintf("accounts/%ss/%d", field.name, account.ID), image.Ext)
+			if err != nil {
+				httperror.InternalServerError(w, err)
+				return
+			}
+			url, err := h.app.Storage.Put(ctx, key, bytes.NewReader(image.Content), image.ContentType)
+			if err != nil {
+				httperror.InternalServerError(w, err)
+				return
+			}
+			*field.target = &url
+		}
+	}
+
+	repo := h.app.Dao.Account()
+	if err := repo.Update(ctx, &updated); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	result, err := repo.RetrieveByID(ctx, account.ID)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
@@ -0,0 +1,97 @@
+package apps
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	tests := []struct {
+		name     string
+		body     *AddRequest
+		mockFunc func()
+		wantCode int
+	}{
+		{
+			name: "successfully register application",
+			body: &AddRequest{
+				ClientName:   "testapp",
+				RedirectURIs: "https://example.com/callback urn:ietf:wg:oauth:2.0:oob",
+				Scopes:       "read write:statuses",
+			},
+			mockFunc: func() {
+				mock.ExpectExec("insert into application \\(name, website, redirect_uri, scopes, client_id, client_secret\\) values \\(\\?, \\?, \\?, \\?, \\?, \\?\\)").
+					WithArgs("testapp", nil, "https://example.com/callback\nurn:ietf:wg:oauth:2.0:oob", "read write:statuses", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "unknown scope",
+			body:     &AddRequest{ClientName: "testapp", RedirectURIs: "https://example.com/callback", Scopes: "admin"},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "relative redirect uri",
+			body:     &AddRequest{ClientName: "testapp", RedirectURIs: "/callback"},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "missing client name",
+			body:     &AddRequest{RedirectURIs: "https://example.com/callback"},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bodyBytes, err := json.Marshal(tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, err := http.NewRequest(http.MethodPost, "/v1/apps", bytes.NewReader(bodyBytes))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.mockFunc != nil {
+				tt.mockFunc()
+			}
+
+			w := httptest.NewRecorder()
+			h.Create(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode == http.StatusOK {
+				var resp object.Application
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+				assert.NotEmpty(t, resp.ClientID)
+				assert.NotEmpty(t, resp.ClientSecret)
+			}
+		})
+	}
+}
+
+func newMockHandler(db *sql.DB) *handler {
+	return &handler{
+		app: &app.App{
+			Dao: dao.NewWithDB(sqlx.NewDb(db, "sqlmock")),
+		},
+	}
+}
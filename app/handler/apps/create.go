@@ -0,0 +1,86 @@
+package apps
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/httperror"
+
+	"github.com/pkg/errors"
+)
+
+type AddRequest struct {
+	ClientName string `json:"client_name"`
+	// Newline separated redirect URIs
+	RedirectURIs string  `json:"redirect_uris"`
+	Scopes       string  `json:"scopes"`
+	Website      *string `json:"website"`
+}
+
+// Handle request for `POST /v1/apps`
+func (h *handler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req AddRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	if req.ClientName == "" {
+		httperror.BadRequest(w, errors.New("client_name is required"))
+		return
+	}
+
+	redirectURIs, err := parseRedirectURIs(req.RedirectURIs)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	scopes := req.Scopes
+	if scopes == "" {
+		scopes = object.DefaultScopes
+	}
+	if _, ok := object.ParseScopes(scopes); !ok {
+		httperror.BadRequest(w, errors.Errorf("invalid scopes: %s", scopes))
+		return
+	}
+
+	application, err := object.NewApplication(req.ClientName, redirectURIs, scopes, req.Website)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if err := h.app.Dao.Application().Create(ctx, application); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(application); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
+
+func parseRedirectURIs(s string) ([]string, error) {
+	var uris []string
+	for _, uri := range strings.Fields(s) {
+		if uri != object.RedirectURIOutOfBand {
+			u, err := url.Parse(uri)
+			if err != nil || !u.IsAbs() || u.Fragment != "" {
+				return nil, errors.Errorf("invalid redirect uri: %s", uri)
+			}
+		}
+		uris = append(uris, uri)
+	}
+
+	if len(uris) == 0 {
+		return nil, errors.New("redirect_uris is required")
+	}
+	return uris, nil
+}
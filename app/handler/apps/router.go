@@ -0,0 +1,23 @@
+package apps
+
+import (
+	"net/http"
+
+	"yatter-backend-go/app/app"
+
+	"github.com/go-chi/chi"
+)
+
+type handler struct {
+	app *app.App
+}
+
+// Create Handler for `/v1/apps/`
+func NewRouter(app *app.App) http.Handler {
+	r := chi.NewRouter()
+
+	h := &handler{app: app}
+	r.Post("/", h.Create)
+
+	return r
+}
@@ -2,50 +2,101 @@ package auth
 
 import (
 	"context"
+	"database/sql"
 	"net/http"
 	"strings"
+	"time"
 
 	"yatter-backend-go/app/app"
 	"yatter-backend-go/app/domain/object"
 	"yatter-backend-go/app/handler/httperror"
 )
 
-var contextKey = new(struct{})
+// Pointers to zero-size values may be equal, so the keys are distinct values of a private type
+type contextKeyType int
 
-// Auth by header
+const (
+	contextKey contextKeyType = iota
+	tokenContextKey
+)
+
+// Auth by bearer access token
 func Middleware(app *app.App) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
 
-			// ヘッダーから Username を取り出すだけの超安易な認証
-			a := r.Header.Get("Authentication")
-			pair := strings.SplitN(a, " ", 2)
-			if len(pair) < 2 {
+			accessToken, ok := BearerTokenOf(r)
+			if !ok {
 				httperror.Error(w, http.StatusUnauthorized)
 				return
 			}
 
-			authType := pair[0]
-			if !strings.EqualFold(authType, "username") {
+			token, err := app.Dao.Token().Retrieve(ctx, accessToken)
+			if err != nil {
+				if err == sql.ErrNoRows {
+					httperror.Error(w, http.StatusUnauthorized)
+					return
+				}
+				httperror.InternalServerError(w, err)
+				return
+			}
+			if token.IsExpired(time.Now()) {
 				httperror.Error(w, http.StatusUnauthorized)
 				return
 			}
 
-			username := pair[1]
-			if account, err := app.Dao.Account().Retrieve(ctx, username); err != nil {
+			account, err := app.Dao.Account().RetrieveByID(ctx, token.AccountID)
+			if err != nil {
+				if err == sql.ErrNoRows {
+					httperror.Error(w, http.StatusUnauthorized)
+					return
+				}
 				httperror.InternalServerError(w, err)
 				return
-			} else if account == nil {
-				httperror.Error(w, http.StatusUnauthorized)
+			}
+
+			ctx = context.WithValue(ctx, tokenContextKey, token)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, contextKey, account)))
+		})
+	}
+}
+
+// Auth by bearer access token only when the request has `Authorization` header.
+// Requests without it are passed through anonymously.
+// Tokens not granted `read:statuses` are also treated as anonymous,
+// since the viewer can read private and direct statuses.
+func OptionalMiddleware(app *app.App) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				next.ServeHTTP(w, r)
 				return
-			} else {
-				next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey, account)))
 			}
+			Middleware(app)(http.HandlerFunc(func(w http.ResponseWriter, authorized *http.Request) {
+				if !TokenOf(authorized).Allows(object.ScopeReadStatuses) {
+					next.ServeHTTP(w, r)
+					return
+				}
+				next.ServeHTTP(w, authorized)
+			})).ServeHTTP(w, r)
 		})
 	}
 }
 
+// Read the token from `Authorization: Bearer <token>` header
+func BearerTokenOf(r *http.Request) (string, bool) {
+	pair := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+	if len(pair) < 2 {
+		return "", false
+	}
+
+	if !strings.EqualFold(pair[0], "bearer") || pair[1] == "" {
+		return "", false
+	}
+	return pair[1], true
+}
+
 // Read Account data from authorized request
 func AccountOf(r *http.Request) *object.Account {
 	if cv := r.Context().Value(contextKey); cv == nil {
@@ -59,3 +110,11 @@ func AccountOf(r *http.Request) *object.Account {
 
 	}
 }
+
+// Read the access token from authorized request
+func TokenOf(r *http.Request) *object.Token {
+	if token, ok := r.Context().Value(tokenContextKey).(*object.Token); ok {
+		return token
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	defer db.Close()
+	a := &app.App{Dao: dao.NewWithDB(sqlx.NewDb(db, "sqlmock"))}
+
+	tests := []struct {
+		name     string
+		header   string
+		mockFunc func()
+		wantCode int
+	}{
+		{
+			name:   "valid token",
+			header: "Bearer valid",
+			mockFunc: func() {
+				mock.ExpectQuery("select \\* from access_token where token = \\?").
+					WithArgs("valid").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "token", "expires_at"}).
+						AddRow(1, 1, "valid", time.Now().Add(time.Hour)))
+				mock.ExpectQuery("select \\* from account where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "expired token",
+			header: "Bearer expired",
+			mockFunc: func() {
+				mock.ExpectQuery("select \\* from access_token where token = \\?").
+					WithArgs("expired").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "token", "expires_at"}).
+						AddRow(1, 1, "expired", time.Now().Add(-time.Hour)))
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "unknown token",
+			header: "Bearer unknown",
+			mockFunc: func() {
+				mock.ExpectQuery("select \\* from access_token where token = \\?").
+					WithArgs("unknown").
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "username scheme is rejected",
+			header:   "username testuser",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "no header",
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodGet, "/", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			if tt.mockFunc != nil {
+				tt.mockFunc()
+			}
+
+			Middleware(a)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.NotNil(t, AccountOf(r))
+				assert.NotNil(t, TokenOf(r))
+			})).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
+
+func TestOptionalMiddleware(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	defer db.Close()
+	a := &app.App{Dao: dao.NewWithDB(sqlx.NewDb(db, "sqlmock"))}
+
+	tests := []struct {
+		name        string
+		header      string
+		mockFunc    func()
+		wantCode    int
+		wantAccount bool
+	}{
+		{
+			name:   "valid token",
+			header: "Bearer valid",
+			mockFunc: func() {
+				mock.ExpectQuery("select \\* from access_token where token = \\?").
+					WithArgs("valid").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "token", "scopes", "expires_at"}).
+						AddRow(1, 1, "valid", "read", time.Now().Add(time.Hour)))
+				mock.ExpectQuery("select \\* from account where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
+			},
+			wantCode:    http.StatusOK,
+			wantAccount: true,
+		},
+		{
+			name:   "token without read:statuses",
+			header: "Bearer valid",
+			mockFunc: func() {
+				mock.ExpectQuery("select \\* from access_token where token = \\?").
+					WithArgs("valid").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "token", "scopes", "expires_at"}).
+						AddRow(1, 1, "valid", "write:media follow", time.Now().Add(time.Hour)))
+				mock.ExpectQuery("select \\* from account where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
+			},
+			wantCode:    http.StatusOK,
+			wantAccount: false,
+		},
+		{
+			name:   "invalid token",
+			header: "Bearer invalid",
+			mockFunc: func() {
+				mock.ExpectQuery("select \\* from access_token where token = \\?").
+					WithArgs("invalid").
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "anonymous",
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodGet, "/", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			if tt.mockFunc != nil {
+				tt.mockFunc()
+			}
+
+			var gotAccount bool
+			OptionalMiddleware(a)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotAccount = AccountOf(r) != nil
+			})).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantAccount, gotAccount)
+		})
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    *object.Token
+		required string
+		wantCode int
+	}{
+		{name: "granted", token: &object.Token{Scopes: "read"}, required: "read:statuses", wantCode: http.StatusOK},
+		{name: "not granted", token: &object.Token{Scopes: "read"}, required: "write:statuses", wantCode: http.StatusForbidden},
+		{name: "no token", required: "read", wantCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodGet, "/", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.token != nil {
+				r = r.WithContext(context.WithValue(r.Context(), tokenContextKey, tt.token))
+			}
+
+			RequireScope(tt.required)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"net/http"
+
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Reject requests whose access token was not granted the scope
+// Must be used after Middleware
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := TokenOf(r)
+			if token == nil {
+				httperror.Error(w, http.StatusUnauthorized)
+				return
+			}
+			if !token.Allows(scope) {
+				httperror.Error(w, http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
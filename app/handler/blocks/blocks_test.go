@@ -0,0 +1,83 @@
+package blocks
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/testutil"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	testutil.ExpectAuth(mock, 1, "testuser")
+	mock.ExpectQuery("select \\* from block where account_id = \\? AND id <= \\? order by create_at desc limit \\?").
+		WithArgs(1, 10, 40).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "target_account_id"}).AddRow(7, 1, 3).AddRow(6, 1, 2))
+	mock.ExpectQuery("select \\* from account where id in \\(\\?, \\?\\)").
+		WithArgs(3, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "alice").AddRow(3, "bob"))
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest(http.MethodGet, "/v1/blocks?max_id=10", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.SetAuth(r)
+	auth.Middleware(h.app)(http.HandlerFunc(h.List)).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp []object.Block
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, resp, 2) {
+		assert.Equal(t, uint64(7), resp[0].ID)
+		assert.Equal(t, "bob", resp[0].Account.Username)
+		assert.Equal(t, "alice", resp[1].Account.Username)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListHandlerEmpty(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	testutil.ExpectAuth(mock, 1, "testuser")
+	mock.ExpectQuery("select \\* from block where account_id = \\?").
+		WithArgs(1, 40).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "target_account_id"}))
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest(http.MethodGet, "/v1/blocks", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.SetAuth(r)
+	auth.Middleware(h.app)(http.HandlerFunc(h.List)).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func newMockHandler(db *sql.DB) *handler {
+	return &handler{
+		app: &app.App{
+			Dao: dao.NewWithDB(sqlx.NewDb(db, "sqlmock")),
+		},
+	}
+}
@@ -0,0 +1,58 @@
+package blocks
+
+import (
+	"encoding/json"
+	"net/http"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Handle request for `GET /v1/blocks`
+func (h *handler) List(w http.ResponseWriter, r *http.Request) {
+	account := auth.AccountOf(r)
+	if account == nil {
+		httperror.Error(w, http.StatusUnauthorized)
+		return
+	}
+	ctx := r.Context()
+
+	_, max_id, since_id, limit, err := request.ParseQueries(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	blocks, err := h.app.Dao.Block().RetrieveByAccountID(ctx, account.ID, max_id, since_id, limit)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if blocks == nil {
+		blocks = []*object.Block{}
+	}
+
+	ids := make([]object.AccountID, 0, len(blocks))
+	for _, block := range blocks {
+		ids = append(ids, block.TargetAccountID)
+	}
+	accounts, err := h.app.Dao.Account().RetrieveByIDs(ctx, ids)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	accountByID := make(map[object.AccountID]*object.Account, len(accounts))
+	for _, a := range accounts {
+		accountByID[a.ID] = a
+	}
+	for _, block := range blocks {
+		block.Account = accountByID[block.TargetAccountID]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(blocks); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
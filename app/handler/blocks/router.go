@@ -0,0 +1,25 @@
+package blocks
+
+import (
+	"net/http"
+
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+
+	"github.com/go-chi/chi"
+)
+
+type handler struct {
+	app *app.App
+}
+
+// Create Handler for `/v1/blocks/`
+func NewRouter(app *app.App) http.Handler {
+	r := chi.NewRouter()
+
+	h := &handler{app: app}
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeReadBlocks)).Get("/", h.List)
+
+	return r
+}
@@ -0,0 +1,45 @@
+package followrequests
+
+import (
+	"database/sql"
+	"net/http"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Handle request for `POST /v1/follow_requests/{id}/authorize`
+func (h *handler) Authorize(w http.ResponseWriter, r *http.Request) {
+	req, ok := h.retrieveOwn(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.app.Dao.FollowRequest().Authorize(r.Context(), req); err != nil {
+		if err == sql.ErrNoRows {
+			httperror.NotFound(w, req.ID)
+			return
+		}
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	h.writeRelationship(w, r, req)
+}
+
+// Handle request for `POST /v1/follow_requests/{id}/reject`
+func (h *handler) Reject(w http.ResponseWriter, r *http.Request) {
+	req, ok := h.retrieveOwn(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.app.Dao.FollowRequest().Reject(r.Context(), req.ID); err != nil {
+		if err == sql.ErrNoRows {
+			httperror.NotFound(w, req.ID)
+			return
+		}
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	h.writeRelationship(w, r, req)
+}
@@ -0,0 +1,231 @@
+package followrequests
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/testutil"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-chi/chi"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var requestColumns = []string{"id", "account_id", "target_account_id"}
+
+const relationshipQuery = "select account.id, account.username, exists .* as muting_notifications from account where account.username in \\(\\?\\)"
+
+func TestListHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	testutil.ExpectAuth(mock, 1, "testuser")
+	mock.ExpectQuery("select \\* from follow_request where target_account_id = \\? order by create_at desc limit \\?").
+		WithArgs(1, 40).
+		WillReturnRows(sqlmock.NewRows(requestColumns).AddRow(5, 2, 1).AddRow(4, 3, 1))
+	mock.ExpectQuery("select \\* from account where id in \\(\\?, \\?\\)").
+		WithArgs(2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "alice").AddRow(3, "bob"))
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest(http.MethodGet, "/v1/follow_requests", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.SetAuth(r)
+	auth.Middleware(h.app)(http.HandlerFunc(h.List)).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp []object.FollowRequest
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, resp, 2) {
+		assert.Equal(t, uint64(5), resp[0].ID)
+		assert.Equal(t, "alice", resp[0].Account.Username)
+		assert.Equal(t, "bob", resp[1].Account.Username)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListHandlerEmpty(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	testutil.ExpectAuth(mock, 1, "testuser")
+	mock.ExpectQuery("select \\* from follow_request where target_account_id = \\?").
+		WithArgs(1, 40).
+		WillReturnRows(sqlmock.NewRows(requestColumns))
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest(http.MethodGet, "/v1/follow_requests", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.SetAuth(r)
+	auth.Middleware(h.app)(http.HandlerFunc(h.List)).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuthorizeHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "successfully authorize",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from follow_request where id = \\?").
+					WithArgs(5).
+					WillReturnRows(sqlmock.NewRows(requestColumns).AddRow(5, 2, 1))
+				mock.ExpectBegin()
+				mock.ExpectExec("delete from follow_request where id = \\?").
+					WithArgs(5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("insert into relationship \\(following_id, follower_id\\) values \\(\\?, \\?\\)").
+					WithArgs(2, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("update account set following_count = following_count \\+ 1 where id = \\?").
+					WithArgs(2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("update account set followers_count = followers_count \\+ 1 where id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("insert into notification \\(account_id, from_account_id, type, status_id, status_key\\) values").
+					WithArgs(1, 2, "follow", nil, 0).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				mock.ExpectQuery("select \\* from account where id = \\?").
+					WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "alice"))
+				mock.ExpectQuery(relationshipQuery).
+					WithArgs(1, 1, 1, 1, 1, sqlmock.AnyArg(), 1, sqlmock.AnyArg(), "alice").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "following", "followed_by", "requested", "blocking", "muting", "muting_notifications"}).AddRow(2, "alice", false, true, false, false, false, false))
+			},
+			wantCode: http.StatusOK,
+			wantBody: `{"id":2,"following":false,"followed_by":true,"requested":false,"blocking":false,"muting":false,"muting_notifications":false}`,
+		},
+		{
+			name: "request to another account",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from follow_request where id = \\?").
+					WithArgs(5).
+					WillReturnRows(sqlmock.NewRows(requestColumns).AddRow(5, 2, 3))
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "not found",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from follow_request where id = \\?").
+					WithArgs(5).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "already handled",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from follow_request where id = \\?").
+					WithArgs(5).
+					WillReturnRows(sqlmock.NewRows(requestColumns).AddRow(5, 2, 1))
+				mock.ExpectBegin()
+				mock.ExpectExec("delete from follow_request where id = \\?").
+					WithArgs(5).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodPost, "/v1/follow_requests/5/authorize", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r = setChiURLParam(r, "id", "5")
+			testutil.SetAuth(r)
+			tt.mockFunc()
+			auth.Middleware(h.app)(http.HandlerFunc(h.Authorize)).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRejectHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	testutil.ExpectAuth(mock, 1, "testuser")
+	mock.ExpectQuery("select \\* from follow_request where id = \\?").
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows(requestColumns).AddRow(5, 2, 1))
+	mock.ExpectExec("delete from follow_request where id = \\?").
+		WithArgs(5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("select \\* from account where id = \\?").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "alice"))
+	mock.ExpectQuery(relationshipQuery).
+		WithArgs(1, 1, 1, 1, 1, sqlmock.AnyArg(), 1, sqlmock.AnyArg(), "alice").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "following", "followed_by", "requested", "blocking", "muting", "muting_notifications"}).AddRow(2, "alice", false, false, false, false, false, false))
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest(http.MethodPost, "/v1/follow_requests/5/reject", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r = setChiURLParam(r, "id", "5")
+	testutil.SetAuth(r)
+	auth.Middleware(h.app)(http.HandlerFunc(h.Reject)).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":2,"following":false,"followed_by":false,"requested":false,"blocking":false,"muting":false,"muting_notifications":false}`, w.Body.String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func newMockHandler(db *sql.DB) *handler {
+	return &handler{
+		app: &app.App{
+			Dao: dao.NewWithDB(sqlx.NewDb(db, "sqlmock")),
+		},
+	}
+}
+
+func setChiURLParam(r *http.Request, key, value string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
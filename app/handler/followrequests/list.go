@@ -0,0 +1,58 @@
+package followrequests
+
+import (
+	"encoding/json"
+	"net/http"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Handle request for `GET /v1/follow_requests`
+func (h *handler) List(w http.ResponseWriter, r *http.Request) {
+	account := auth.AccountOf(r)
+	if account == nil {
+		httperror.Error(w, http.StatusUnauthorized)
+		return
+	}
+	ctx := r.Context()
+
+	_, max_id, since_id, limit, err := request.ParseQueries(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	requests, err := h.app.Dao.FollowRequest().RetrieveByTargetID(ctx, account.ID, max_id, since_id, limit)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if requests == nil {
+		requests = []*object.FollowRequest{}
+	}
+
+	ids := make([]object.AccountID, 0, len(requests))
+	for _, req := range requests {
+		ids = append(ids, req.AccountID)
+	}
+	accounts, err := h.app.Dao.Account().RetrieveByIDs(ctx, ids)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	accountByID := make(map[object.AccountID]*object.Account, len(accounts))
+	for _, a := range accounts {
+		accountByID[a.ID] = a
+	}
+	for _, req := range requests {
+		req.Account = accountByID[req.AccountID]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(requests); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
@@ -0,0 +1,27 @@
+package followrequests
+
+import (
+	"net/http"
+
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+
+	"github.com/go-chi/chi"
+)
+
+type handler struct {
+	app *app.App
+}
+
+// Create Handler for `/v1/follow_requests/`
+func NewRouter(app *app.App) http.Handler {
+	r := chi.NewRouter()
+
+	h := &handler{app: app}
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeReadFollows)).Get("/", h.List)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeWriteFollows)).Post("/{id}/authorize", h.Authorize)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeWriteFollows)).Post("/{id}/reject", h.Reject)
+
+	return r
+}
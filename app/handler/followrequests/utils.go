@@ -0,0 +1,67 @@
+package followrequests
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Retrieve the follow request of path parameter `id`.
+// Requests addressed to other accounts are treated as not found.
+func (h *handler) retrieveOwn(w http.ResponseWriter, r *http.Request) (*object.FollowRequest, bool) {
+	account := auth.AccountOf(r)
+	if account == nil {
+		httperror.Error(w, http.StatusUnauthorized)
+		return nil, false
+	}
+
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return nil, false
+	}
+
+	req, err := h.app.Dao.FollowRequest().Retrieve(r.Context(), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			httperror.NotFound(w, id)
+			return nil, false
+		}
+		httperror.InternalServerError(w, err)
+		return nil, false
+	}
+	if req.TargetAccountID != account.ID {
+		httperror.NotFound(w, id)
+		return nil, false
+	}
+	return req, true
+}
+
+// Write the relationship between the caller and the account which requested to follow
+func (h *handler) writeRelationship(w http.ResponseWriter, r *http.Request, req *object.FollowRequest) {
+	ctx := r.Context()
+	requester, err := h.app.Dao.Account().RetrieveByID(ctx, req.AccountID)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	relationships, err := h.app.Dao.Relationship().RetrieveByUsernames(ctx, req.TargetAccountID, []string{requester.Username})
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if len(relationships) == 0 {
+		httperror.NotFound(w, requester.Username)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(relationships[0]); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
@@ -22,6 +22,11 @@ func InternalServerError(w http.ResponseWriter, err error) {
 	Error(w, http.StatusInternalServerError)
 }
 
+// Response with Unprocessable Entity (422) and a message safe to show to the client
+func UnprocessableEntity(w http.ResponseWriter, message string) {
+	http.Error(w, message, http.StatusUnprocessableEntity)
+}
+
 func NotFound(w http.ResponseWriter, value interface{}) {
 	log.Printf("[NotFound] %+v", value)
 
@@ -0,0 +1,116 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"log"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+
+	"github.com/pkg/errors"
+)
+
+// Name of the request header carrying the key
+const Header = "Idempotency-Key"
+
+// Interval to check whether the first request with the same key has finished
+var pollInterval = 100 * time.Millisecond
+
+// Replay the response of the first request for repeated requests with the same `Idempotency-Key`.
+// Repeats received while the first request is in flight wait for its response.
+// Keys are scoped per account, so this must be used after auth.Middleware.
+func Middleware(app *app.App) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(Header)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			// varchar の長さに合わせてバイト数ではなく文字数で数える
+			if utf8.RuneCountInString(key) > object.MaxIdempotencyKeyLength {
+				httperror.BadRequest(w, errors.Errorf("%s must be at most %d characters", Header, object.MaxIdempotencyKeyLength))
+				return
+			}
+			account := auth.AccountOf(r)
+			if account == nil {
+				httperror.Error(w, http.StatusUnauthorized)
+				return
+			}
+			ctx := r.Context()
+			repo := app.Dao.IdempotencyKey()
+
+			for {
+				reserved, err := repo.Reserve(ctx, account.ID, key, time.Now())
+				if err != nil {
+					httperror.InternalServerError(w, err)
+					return
+				}
+				if reserved {
+					break
+				}
+
+				entry, err := repo.Retrieve(ctx, account.ID, key)
+				if err == sql.ErrNoRows {
+					// 先のリクエストが失敗して解放された
+					continue
+				}
+				if err != nil {
+					httperror.InternalServerError(w, err)
+					return
+				}
+				if entry.Response != nil {
+					w.Header().Set("Content-Type", "application/json")
+					w.Write([]byte(*entry.Response))
+					return
+				}
+
+				select {
+				case <-ctx.Done():
+					httperror.Error(w, http.StatusConflict)
+					return
+				case <-time.After(pollInterval):
+				}
+			}
+
+			rec := &recorder{ResponseWriter: w, code: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			// クライアントが切断していても結果は保存する
+			if rec.code == http.StatusOK {
+				if err := repo.Complete(context.Background(), account.ID, key, rec.body.String()); err != nil {
+					log.Printf("[idempotency] store response: %+v", err)
+				}
+				return
+			}
+			// 失敗したリクエストは再試行できるようにする
+			if err := repo.Release(context.Background(), account.ID, key); err != nil {
+				log.Printf("[idempotency] release key: %+v", err)
+			}
+		})
+	}
+}
+
+// Writes the response through while keeping a copy of it
+type recorder struct {
+	http.ResponseWriter
+	code int
+	body bytes.Buffer
+}
+
+func (r *recorder) WriteHeader(code int) {
+	r.code = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,172 @@
+package idempotency
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/testutil"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	defer db.Close()
+	a := &app.App{Dao: dao.NewWithDB(sqlx.NewDb(db, "sqlmock"))}
+	pollInterval = time.Millisecond
+
+	const reserveQuery = "insert ignore into idempotency_key \\(account_id, idempotency_key, create_at\\) values \\(\\?, \\?, \\?\\)"
+	expectReserve := func(reserved bool) {
+		mock.ExpectExec("delete from idempotency_key where account_id = \\? and idempotency_key = \\? and \\(create_at <= \\? or \\(response is null and create_at <= \\?\\)\\)").
+			WithArgs(1, "key", sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		var n int64
+		if reserved {
+			n = 1
+		}
+		mock.ExpectExec(reserveQuery).
+			WithArgs(1, "key", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, n))
+	}
+	expectRetrieve := func(response interface{}) {
+		mock.ExpectQuery("select \\* from idempotency_key where account_id = \\? and idempotency_key = \\?").
+			WithArgs(1, "key").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "idempotency_key", "response"}).AddRow(1, 1, "key", response))
+	}
+
+	tests := []struct {
+		name      string
+		key       string
+		nextCode  int
+		mockFunc  func()
+		wantCode  int
+		wantBody  string
+		wantCalls int
+	}{
+		{
+			name:      "without key",
+			nextCode:  http.StatusOK,
+			wantCode:  http.StatusOK,
+			wantBody:  `{"id":1}`,
+			wantCalls: 1,
+		},
+		{
+			name:     "first request stores response",
+			key:      "key",
+			nextCode: http.StatusOK,
+			mockFunc: func() {
+				expectReserve(true)
+				mock.ExpectExec("update idempotency_key set response = \\? where account_id = \\? and idempotency_key = \\?").
+					WithArgs(`{"id":1}`, 1, "key").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantCode:  http.StatusOK,
+			wantBody:  `{"id":1}`,
+			wantCalls: 1,
+		},
+		{
+			name: "repeat replays stored response",
+			key:  "key",
+			mockFunc: func() {
+				expectReserve(false)
+				expectRetrieve(`{"id":1}`)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `{"id":1}`,
+		},
+		{
+			name: "repeat waits for request in flight",
+			key:  "key",
+			mockFunc: func() {
+				expectReserve(false)
+				expectRetrieve(nil)
+				expectReserve(false)
+				expectRetrieve(`{"id":1}`)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `{"id":1}`,
+		},
+		{
+			name:     "failed request releases key",
+			key:      "key",
+			nextCode: http.StatusBadRequest,
+			mockFunc: func() {
+				expectReserve(true)
+				mock.ExpectExec("delete from idempotency_key where account_id = \\? and idempotency_key = \\? and response is null").
+					WithArgs(1, "key").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantCode:  http.StatusBadRequest,
+			wantCalls: 1,
+		},
+		{
+			name:     "too long key",
+			key:      strings.Repeat("k", 256),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "multibyte key counted in characters",
+			key:      strings.Repeat("鍵", 255),
+			nextCode: http.StatusOK,
+			mockFunc: func() {
+				key := strings.Repeat("鍵", 255)
+				mock.ExpectExec("delete from idempotency_key where account_id = \\? and idempotency_key = \\? and \\(create_at <= \\? or \\(response is null and create_at <= \\?\\)\\)").
+					WithArgs(1, key, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(reserveQuery).
+					WithArgs(1, key, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("update idempotency_key set response = \\? where account_id = \\? and idempotency_key = \\?").
+					WithArgs(`{"id":1}`, 1, key).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantCode:  http.StatusOK,
+			wantBody:  `{"id":1}`,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if tt.nextCode != http.StatusOK {
+					http.Error(w, "bad request", tt.nextCode)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"id":1}`))
+			})
+
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodPost, "/v1/statuses", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutil.SetAuth(r)
+			if tt.key != "" {
+				r.Header.Set(Header, tt.key)
+			}
+			testutil.ExpectAuth(mock, 1, "testuser")
+			if tt.mockFunc != nil {
+				tt.mockFunc()
+			}
+			auth.Middleware(a)(Middleware(a)(next)).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
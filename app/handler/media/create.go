@@ -0,0 +1,96 @@
+package media
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/upload"
+	"yatter-backend-go/app/storage"
+)
+
+const (
+	// Maximum size of uploaded images
+	maxImageSize = 8 << 20
+
+	// Maximum size of uploaded videos
+	maxVideoSize = 40 << 20
+
+	maxMultipartMemory = 8 << 20
+)
+
+// Handle request for `POST /v1/media`
+func (h *handler) Create(w http.ResponseWriter, r *http.Request) {
+	account := auth.AccountOf(r)
+	if account == nil {
+		httperror.Error(w, http.StatusUnauthorized)
+		return
+	}
+	ctx := r.Context()
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxVideoSize+maxMultipartMemory)
+	if err := r.ParseMultipartForm(maxMultipartMemory); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	attachment := new(object.Attachment)
+	attachment.AccountID = account.ID
+	if values, ok := r.PostForm["description"]; ok {
+		if err := attachment.SetDescription(values[0]); err != nil {
+			httperror.BadRequest(w, err)
+			return
+		}
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	media, err := upload.Read(file, maxVideoSize, upload.ImageTypes, upload.VideoTypes)
+	file.Close()
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	attachment.Type = object.AttachmentTypeVideo
+	if _, ok := upload.ImageTypes[media.ContentType]; ok {
+		if len(media.Content) > maxImageSize {
+			httperror.BadRequest(w, fmt.Errorf("image must be at most %d bytes", maxImageSize))
+			return
+		}
+		attachment.Type = object.AttachmentTypeImage
+	}
+
+	key, err := storage.NewKey(fmt.Sprintf("media_attachments/%d", account.ID), media.Ext)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if attachment.URL, err = h.app.Storage.Put(ctx, key, bytes.NewReader(media.Content), media.ContentType); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	if err := h.app.Dao.Attachment().Create(ctx, attachment); err != nil {
+		// どこからも参照されないファイルを残さない
+		if err := h.app.Storage.Delete(ctx, key); err != nil {
+			log.Printf("[media] delete %s: %+v", key, err)
+		}
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(attachment); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
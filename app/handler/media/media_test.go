@@ -0,0 +1,185 @@
+package media
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/testutil"
+	"yatter-backend-go/app/storage"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db, storage.NewLocal(t.TempDir(), "/media"))
+	defer db.Close()
+
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 64)...)
+
+	tests := []struct {
+		name        string
+		file        []byte
+		description string
+		isAuth      bool
+		mockFunc    func()
+		wantCode    int
+		wantType    string
+	}{
+		{
+			name:        "successfully upload image",
+			file:        png,
+			description: "a picture",
+			isAuth:      true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectExec("insert into attachment \\(account_id, type, url, description\\) values \\(\\?, \\?, \\?, \\?\\)").
+					WithArgs(1, "image", sqlmock.AnyArg(), "a picture").
+					WillReturnResult(sqlmock.NewResult(10, 1))
+			},
+			wantCode: http.StatusOK,
+			wantType: "image",
+		},
+		{
+			name:   "unsupported file",
+			file:   []byte("plain text"),
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:   "missing file",
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unauthorized",
+			file:     png,
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := new(bytes.Buffer)
+			mw := multipart.NewWriter(body)
+			if tt.description != "" {
+				if err := mw.WriteField("description", tt.description); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.file != nil {
+				fw, err := mw.CreateFormFile("file", "file")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err := fw.Write(tt.file); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := mw.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			r, err := http.NewRequest(http.MethodPost, "/v1/media", body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.Header.Set("Content-Type", mw.FormDataContentType())
+			if tt.isAuth {
+				testutil.SetAuth(r)
+			}
+			if tt.mockFunc != nil {
+				tt.mockFunc()
+			}
+
+			w := httptest.NewRecorder()
+			auth.Middleware(h.app)(http.HandlerFunc(h.Create)).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode == http.StatusOK {
+				var resp object.Attachment
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, uint64(10), resp.ID)
+				assert.Equal(t, tt.wantType, resp.Type)
+				assert.NotEmpty(t, resp.URL)
+			}
+		})
+	}
+}
+
+func TestCreateHandlerDeletesFileOnError(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	dir := t.TempDir()
+	h := newMockHandler(db, storage.NewLocal(dir, "/media"))
+	defer db.Close()
+
+	body := new(bytes.Buffer)
+	mw := multipart.NewWriter(body)
+	fw, err := mw.CreateFormFile("file", "file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fw.Write(append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 64)...)); err != nil {
+		t.Fatal(err)
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := http.NewRequest(http.MethodPost, "/v1/media", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	testutil.SetAuth(r)
+
+	testutil.ExpectAuth(mock, 1, "testuser")
+	mock.ExpectExec("insert into attachment \\(account_id, type, url, description\\) values \\(\\?, \\?, \\?, \\?\\)").
+		WillReturnError(errors.New("insert failed"))
+
+	w := httptest.NewRecorder()
+	auth.Middleware(h.app)(http.HandlerFunc(h.Create)).ServeHTTP(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// 保存したファイルは消されている
+	var files []string
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			files = append(files, path)
+		}
+		return err
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func newMockHandler(db *sql.DB, s storage.Storage) *handler {
+	return &handler{
+		app: &app.App{
+			Dao:     dao.NewWithDB(sqlx.NewDb(db, "sqlmock")),
+			Storage: s,
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package mutes
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Handle request for `GET /v1/mutes`
+func (h *handler) List(w http.ResponseWriter, r *http.Request) {
+	account := auth.AccountOf(r)
+	if account == nil {
+		httperror.Error(w, http.StatusUnauthorized)
+		return
+	}
+	ctx := r.Context()
+
+	_, max_id, since_id, limit, err := request.ParseQueries(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	mutes, err := h.app.Dao.Mute().RetrieveByAccountID(ctx, account.ID, time.Now(), max_id, since_id, limit)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if mutes == nil {
+		mutes = []*object.Mute{}
+	}
+
+	ids := make([]object.AccountID, 0, len(mutes))
+	for _, mute := range mutes {
+		ids = append(ids, mute.TargetAccountID)
+	}
+	accounts, err := h.app.Dao.Account().RetrieveByIDs(ctx, ids)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	accountByID := make(map[object.AccountID]*object.Account, len(accounts))
+	for _, a := range accounts {
+		accountByID[a.ID] = a
+	}
+	for _, mute := range mutes {
+		mute.Account = accountByID[mute.TargetAccountID]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(mutes); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
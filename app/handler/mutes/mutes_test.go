@@ -0,0 +1,89 @@
+package mutes
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/testutil"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	testutil.ExpectAuth(mock, 1, "testuser")
+	mock.ExpectQuery("select \\* from mute where account_id = \\? AND \\(mute.expires_at is null or mute.expires_at > \\?\\) AND id <= \\? order by create_at desc limit \\?").
+		WithArgs(1, sqlmock.AnyArg(), 10, 40).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "target_account_id", "hide_notifications", "expires_at"}).
+			AddRow(7, 1, 3, true, nil).
+			AddRow(6, 1, 2, false, time.Now().Add(time.Hour)))
+	mock.ExpectQuery("select \\* from account where id in \\(\\?, \\?\\)").
+		WithArgs(3, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "alice").AddRow(3, "bob"))
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest(http.MethodGet, "/v1/mutes?max_id=10", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.SetAuth(r)
+	auth.Middleware(h.app)(http.HandlerFunc(h.List)).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp []object.Mute
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, resp, 2) {
+		assert.Equal(t, uint64(7), resp[0].ID)
+		assert.Equal(t, "bob", resp[0].Account.Username)
+		assert.True(t, resp[0].HideNotifications)
+		assert.Nil(t, resp[0].ExpiresAt)
+		assert.Equal(t, "alice", resp[1].Account.Username)
+		assert.NotNil(t, resp[1].ExpiresAt)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListHandlerEmpty(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	testutil.ExpectAuth(mock, 1, "testuser")
+	mock.ExpectQuery("select \\* from mute where account_id = \\?").
+		WithArgs(1, sqlmock.AnyArg(), 40).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "target_account_id"}))
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest(http.MethodGet, "/v1/mutes", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.SetAuth(r)
+	auth.Middleware(h.app)(http.HandlerFunc(h.List)).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func newMockHandler(db *sql.DB) *handler {
+	return &handler{
+		app: &app.App{
+			Dao: dao.NewWithDB(sqlx.NewDb(db, "sqlmock")),
+		},
+	}
+}
@@ -0,0 +1,28 @@
+package notifications
+
+import (
+	"encoding/json"
+	"net/http"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Handle request for `POST /v1/notifications/clear`
+func (h *handler) Clear(w http.ResponseWriter, r *http.Request) {
+	account := auth.AccountOf(r)
+	if account == nil {
+		httperror.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.app.Dao.Notification().Clear(r.Context(), account.ID); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(struct{}{}); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
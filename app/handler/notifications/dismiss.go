@@ -0,0 +1,49 @@
+package notifications
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+type DismissRequest struct {
+	// The ID of the notification to dismiss
+	ID uint64 `json:"id"`
+}
+
+// Handle request for `POST /v1/notifications/dismiss`
+func (h *handler) Dismiss(w http.ResponseWriter, r *http.Request) {
+	account := auth.AccountOf(r)
+	if account == nil {
+		httperror.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	var req DismissRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if req.ID == 0 {
+		httperror.BadRequest(w, fmt.Errorf("id was not presence"))
+		return
+	}
+
+	if err := h.app.Dao.Notification().Dismiss(r.Context(), account.ID, req.ID); err != nil {
+		if err == sql.ErrNoRows {
+			httperror.NotFound(w, req.ID)
+			return
+		}
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(struct{}{}); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
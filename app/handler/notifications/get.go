@@ -0,0 +1,52 @@
+package notifications
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/presenter"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Handle request for `GET /v1/notifications/{id}`
+func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
+	account := auth.AccountOf(r)
+	if account == nil {
+		httperror.Error(w, http.StatusUnauthorized)
+		return
+	}
+	ctx := r.Context()
+
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	notification, err := h.app.Dao.Notification().Retrieve(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			httperror.NotFound(w, id)
+			return
+		}
+		httperror.InternalServerError(w, err)
+		return
+	}
+	// 他人の通知は存在しないものとして扱う
+	if notification.AccountID != account.ID {
+		httperror.NotFound(w, id)
+		return
+	}
+	if err := presenter.Notification(ctx, h.app.Dao, account, notification); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(notification); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
@@ -0,0 +1,71 @@
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/presenter"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Handle request for `GET /v1/notifications`
+func (h *handler) List(w http.ResponseWriter, r *http.Request) {
+	account := auth.AccountOf(r)
+	if account == nil {
+		httperror.Error(w, http.StatusUnauthorized)
+		return
+	}
+	ctx := r.Context()
+
+	_, max_id, since_id, limit, err := request.ParseQueries(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	types, err := typesQuery(r, "types")
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	excludeTypes, err := typesQuery(r, "exclude_types")
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	filter := object.NotificationFilter{Types: types, ExcludeTypes: excludeTypes}
+
+	notifications, err := h.app.Dao.Notification().RetrieveByAccountID(ctx, account.ID, filter, max_id, since_id, limit)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if notifications == nil {
+		notifications = []*object.Notification{}
+	}
+	if err := presenter.Notifications(ctx, h.app.Dao, account, notifications); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(notifications); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
+
+// Read notification types of query parameter key, given as `key[]=a&key[]=b` or `key=a`
+func typesQuery(r *http.Request, key string) ([]string, error) {
+	query := r.URL.Query()
+	var types []string
+	for _, t := range append(query[key+"[]"], query[key]...) {
+		if !object.IsNotificationType(t) {
+			return nil, fmt.Errorf("unknown notification type: %q", t)
+		}
+		types = append(types, t)
+	}
+	return types, nil
+}
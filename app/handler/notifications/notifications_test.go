@@ -0,0 +1,312 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/testutil"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-chi/chi"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var notificationColumns = []string{"id", "account_id", "from_account_id", "type", "status_id"}
+
+const listQuery = "select \\* from notification where account_id = \\? AND from_account_id not in \\(select target_account_id from block where account_id = \\? union select target_account_id from mute where account_id = \\? and hide_notifications = 1 and \\(mute.expires_at is null or mute.expires_at > \\?\\)\\)"
+
+// Expect the queries filling in the status 10 of the user for the user
+func expectPresentStatus(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("select \\* from status where id in \\(\\?\\)").
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility"}).AddRow(10, 1, "test post", "public"))
+	mock.ExpectQuery("select \\* from account where id in \\(\\?\\)").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
+	mock.ExpectQuery("select \\* from attachment where status_id in \\(\\?\\) order by id").
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "status_id", "type", "url"}))
+	mock.ExpectQuery("select mention\\.status_id, mention\\.account_id, account\\.username from mention join account on account\\.id = mention\\.account_id where mention\\.status_id in \\(\\?\\) order by mention\\.id").
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"status_id", "account_id", "username"}))
+	mock.ExpectQuery("select tag\\.\\*, status_tag\\.status_id from tag join status_tag on status_tag\\.tag_id = tag\\.id where status_tag\\.status_id in \\(\\?\\) order by status_tag\\.status_id, tag\\.name").
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status_id"}))
+	mock.ExpectQuery("select status_id from favourite where account_id = \\? and status_id in \\(\\?\\)").
+		WithArgs(1, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"status_id"}))
+	mock.ExpectQuery("select reblog_of_id from status where account_id = \\? and reblog_of_id in \\(\\?\\)").
+		WithArgs(1, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"reblog_of_id"}))
+}
+
+func TestListHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	testutil.ExpectAuth(mock, 1, "testuser")
+	mock.ExpectQuery(listQuery+" AND type in \\(\\?, \\?\\) AND id <= \\? order by create_at desc limit \\?").
+		WithArgs(1, 1, 1, sqlmock.AnyArg(), "follow", "favourite", 10, 40).
+		WillReturnRows(sqlmock.NewRows(notificationColumns).
+			AddRow(5, 1, 3, "favourite", 10).
+			AddRow(4, 1, 2, "follow", nil))
+	mock.ExpectQuery("select \\* from account where id in \\(\\?, \\?\\)").
+		WithArgs(3, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "alice").AddRow(3, "bob"))
+	expectPresentStatus(mock)
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest(http.MethodGet, "/v1/notifications?types[]=follow&types=favourite&max_id=10", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.SetAuth(r)
+	auth.Middleware(h.app)(http.HandlerFunc(h.List)).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp []object.Notification
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, resp, 2) {
+		assert.Equal(t, uint64(5), resp[0].ID)
+		assert.Equal(t, "favourite", resp[0].Type)
+		assert.Equal(t, "bob", resp[0].Account.Username)
+		if assert.NotNil(t, resp[0].Status) {
+			assert.Equal(t, "test post", resp[0].Status.Content)
+			assert.Equal(t, "testuser", resp[0].Status.Account.Username)
+		}
+		assert.Equal(t, "follow", resp[1].Type)
+		assert.Equal(t, "alice", resp[1].Account.Username)
+		assert.Nil(t, resp[1].Status)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListHandlerExcludeTypes(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	testutil.ExpectAuth(mock, 1, "testuser")
+	mock.ExpectQuery(listQuery+" AND type not in \\(\\?\\) order by create_at desc limit \\?").
+		WithArgs(1, 1, 1, sqlmock.AnyArg(), "mention", 40).
+		WillReturnRows(sqlmock.NewRows(notificationColumns))
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest(http.MethodGet, "/v1/notifications?exclude_types[]=mention", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.SetAuth(r)
+	auth.Middleware(h.app)(http.HandlerFunc(h.List)).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListHandlerUnknownType(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	testutil.ExpectAuth(mock, 1, "testuser")
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest(http.MethodGet, "/v1/notifications?types[]=poke", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.SetAuth(r)
+	auth.Middleware(h.app)(http.HandlerFunc(h.List)).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	tests := []struct {
+		name     string
+		id       string
+		mockFunc func()
+		wantCode int
+	}{
+		{
+			name: "successfully get notification",
+			id:   "5",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from notification where id = \\?").
+					WithArgs(5).
+					WillReturnRows(sqlmock.NewRows(notificationColumns).AddRow(5, 1, 2, "mention", 10))
+				mock.ExpectQuery("select \\* from account where id in \\(\\?\\)").
+					WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "alice"))
+				expectPresentStatus(mock)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "notification of other account",
+			id:   "5",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from notification where id = \\?").
+					WithArgs(5).
+					WillReturnRows(sqlmock.NewRows(notificationColumns).AddRow(5, 3, 2, "follow", nil))
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "notification not found",
+			id:   "5",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from notification where id = \\?").
+					WithArgs(5).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodGet, "/v1/notifications/"+tt.id, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutil.SetAuth(r)
+			r = setChiURLParam(r, "id", tt.id)
+			auth.Middleware(h.app)(http.HandlerFunc(h.Get)).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode == http.StatusOK {
+				var resp object.Notification
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, "mention", resp.Type)
+				assert.Equal(t, "alice", resp.Account.Username)
+				assert.NotNil(t, resp.Status)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestClearHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	testutil.ExpectAuth(mock, 1, "testuser")
+	mock.ExpectExec("delete from notification where account_id = \\?").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest(http.MethodPost, "/v1/notifications/clear", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.SetAuth(r)
+	auth.Middleware(h.app)(http.HandlerFunc(h.Clear)).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{}`, w.Body.String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDismissHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	tests := []struct {
+		name     string
+		body     string
+		mockFunc func()
+		wantCode int
+	}{
+		{
+			name: "successfully dismiss notification",
+			body: `{"id":5}`,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectExec("delete from notification where id = \\? and account_id = \\?").
+					WithArgs(5, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "notification not found",
+			body: `{"id":5}`,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectExec("delete from notification where id = \\? and account_id = \\?").
+					WithArgs(5, 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "missing id",
+			body: `{}`,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodPost, "/v1/notifications/dismiss", bytes.NewBufferString(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutil.SetAuth(r)
+			auth.Middleware(h.app)(http.HandlerFunc(h.Dismiss)).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func newMockHandler(db *sql.DB) *handler {
+	return &handler{
+		app: &app.App{
+			Dao: dao.NewWithDB(sqlx.NewDb(db, "sqlmock")),
+		},
+	}
+}
+
+func setChiURLParam(r *http.Request, key, value string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
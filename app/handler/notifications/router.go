@@ -0,0 +1,28 @@
+package notifications
+
+import (
+	"net/http"
+
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+
+	"github.com/go-chi/chi"
+)
+
+type handler struct {
+	app *app.App
+}
+
+// Create Handler for `/v1/notifications/`
+func NewRouter(app *app.App) http.Handler {
+	r := chi.NewRouter()
+
+	h := &handler{app: app}
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeReadNotifications)).Get("/", h.List)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeReadNotifications)).Get("/{id}", h.Get)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeWriteNotifications)).Post("/clear", h.Clear)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeWriteNotifications)).Post("/dismiss", h.Dismiss)
+
+	return r
+}
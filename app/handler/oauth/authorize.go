@@ -0,0 +1,138 @@
+package oauth
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+const (
+	responseTypeCode      = "code"
+	authorizationLifetime = 10 * time.Minute
+)
+
+type AuthorizeRequest struct {
+	ResponseType        string `json:"response_type"`
+	ClientID            string `json:"client_id"`
+	RedirectURI         string `json:"redirect_uri"`
+	Scope               string `json:"scope"`
+	State               string `json:"state"`
+	CodeChallenge       string `json:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method"`
+
+	// Credentials of the account approving the authorization
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type AuthorizeResponse struct {
+	Code  string `json:"code"`
+	State string `json:"state,omitempty"`
+}
+
+// Handle request for `POST /v1/oauth/authorize`
+func (h *handler) Authorize(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req AuthorizeRequest
+	if err := decodeBody(r, &req, func(get func(string) string) {
+		req.ResponseType = get("response_type")
+		req.ClientID = get("client_id")
+		req.RedirectURI = get("redirect_uri")
+		req.Scope = get("scope")
+		req.State = get("state")
+		req.CodeChallenge = get("code_challenge")
+		req.CodeChallengeMethod = get("code_challenge_method")
+		req.Username = get("username")
+		req.Password = get("password")
+	}); err != nil {
+		oauthError(w, http.StatusBadRequest, errInvalidRequest, err.Error())
+		return
+	}
+
+	if req.ResponseType != responseTypeCode {
+		oauthError(w, http.StatusBadRequest, errUnsupportedResponseType, "")
+		return
+	}
+
+	application, err := h.app.Dao.Application().RetrieveByClientID(ctx, req.ClientID)
+	if err != nil && err != sql.ErrNoRows {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if application == nil {
+		oauthError(w, http.StatusUnauthorized, errInvalidClient, "")
+		return
+	}
+	if !application.HasRedirectURI(req.RedirectURI) {
+		oauthError(w, http.StatusBadRequest, errInvalidRequest, "redirect_uri is not registered")
+		return
+	}
+
+	scopes := req.Scope
+	if scopes == "" {
+		scopes = object.DefaultScopes
+	}
+	if _, ok := object.ParseScopes(scopes); !ok || !object.ScopesSubset(scopes, application.Scopes) {
+		oauthError(w, http.StatusBadRequest, errInvalidScope, "")
+		return
+	}
+
+	method := req.CodeChallengeMethod
+	if req.CodeChallenge != "" && method == "" {
+		method = object.CodeChallengeMethodPlain
+	}
+	if (req.CodeChallenge == "") != (method == "") ||
+		(method != "" && method != object.CodeChallengeMethodPlain && method != object.CodeChallengeMethodS256) {
+		oauthError(w, http.StatusBadRequest, errInvalidRequest, "invalid code_challenge")
+		return
+	}
+
+	account, err := h.app.Dao.Account().Retrieve(ctx, req.Username)
+	if err != nil && err != sql.ErrNoRows {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if account == nil || !account.CheckPassword(req.Password) {
+		oauthError(w, http.StatusUnauthorized, errAccessDenied, "invalid username or password")
+		return
+	}
+
+	code, err := object.NewAuthorizationCode(application, account.ID, req.RedirectURI, scopes, authorizationLifetime)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	code.CodeChallenge = req.CodeChallenge
+	code.CodeChallengeMethod = method
+	if err := h.app.Dao.AuthorizationCode().Create(ctx, code); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	if req.RedirectURI == object.RedirectURIOutOfBand {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(AuthorizeResponse{Code: code.Code, State: req.State}); err != nil {
+			httperror.InternalServerError(w, err)
+		}
+		return
+	}
+
+	redirect, err := url.Parse(req.RedirectURI)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	query := redirect.Query()
+	query.Set("code", code.Code)
+	if req.State != "" {
+		query.Set("state", req.State)
+	}
+	redirect.RawQuery = query.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
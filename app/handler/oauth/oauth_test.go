@@ -0,0 +1,338 @@
+package oauth
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	account := &object.Account{}
+	if err := account.SetPassword("securepassword"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		body      *TokenRequest
+		bodyBytes []byte
+		mockFunc  func()
+		wantCode  int
+	}{
+		{
+			name: "successfully issue token",
+			body: &TokenRequest{GrantType: "password", Username: "testuser", Password: "securepassword"},
+			mockFunc: func() {
+				mock.ExpectQuery("select \\* from account where username = \\?").
+					WithArgs("testuser").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "password_hash"}).AddRow(1, "testuser", account.PasswordHash))
+				mock.ExpectExec("insert into access_token \\(account_id, application_id, token, scopes, expires_at\\) values \\(\\?, \\?, \\?, \\?, \\?\\)").
+					WithArgs(1, nil, sqlmock.AnyArg(), object.FullScopes, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "wrong password",
+			body: &TokenRequest{GrantType: "password", Username: "testuser", Password: "wrongpassword"},
+			mockFunc: func() {
+				mock.ExpectQuery("select \\* from account where username = \\?").
+					WithArgs("testuser").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "password_hash"}).AddRow(1, "testuser", account.PasswordHash))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "unknown user",
+			body: &TokenRequest{GrantType: "password", Username: "nobody", Password: "securepassword"},
+			mockFunc: func() {
+				mock.ExpectQuery("select \\* from account where username = \\?").
+					WithArgs("nobody").
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "scope outside of client",
+			body: &TokenRequest{GrantType: "password", ClientID: "client", ClientSecret: "secret", Scope: "write", Username: "testuser", Password: "securepassword"},
+			mockFunc: func() {
+				mock.ExpectQuery("select \\* from application where client_id = \\?").
+					WithArgs("client").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "client_id", "client_secret", "scopes"}).AddRow(1, "client", "secret", "read"))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unsupported grant type",
+			body:     &TokenRequest{GrantType: "client_credentials"},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:      "bad request on malformed JSON",
+			bodyBytes: []byte("{malformed}"),
+			wantCode:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.body != nil {
+				tt.bodyBytes, err = json.Marshal(tt.body)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			r, err := http.NewRequest(http.MethodPost, "/v1/oauth/token", bytes.NewReader(tt.bodyBytes))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.mockFunc != nil {
+				tt.mockFunc()
+			}
+
+			w := httptest.NewRecorder()
+			h.Token(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode == http.StatusOK {
+				var resp TokenResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+				assert.NotEmpty(t, resp.AccessToken)
+				assert.Equal(t, "Bearer", resp.TokenType)
+			}
+		})
+	}
+}
+
+func TestTokenHandlerForm(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	account := &object.Account{}
+	if err := account.SetPassword("securepassword"); err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery("select \\* from account where username = \\?").
+		WithArgs("testuser").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "password_hash"}).AddRow(1, "testuser", account.PasswordHash))
+	mock.ExpectExec("insert into access_token").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	form := url.Values{"grant_type": {"password"}, "username": {"testuser"}, "password": {"securepassword"}}
+	r, err := http.NewRequest(http.MethodPost, "/v1/oauth/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	w := httptest.NewRecorder()
+	h.Token(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	account := &object.Account{}
+	if err := account.SetPassword("securepassword"); err != nil {
+		t.Fatal(err)
+	}
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	challenge := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	// authorize
+	mock.ExpectQuery("select \\* from application where client_id = \\?").
+		WithArgs("client").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "client_id", "client_secret", "redirect_uri", "scopes"}).
+			AddRow(1, "client", "secret", "https://example.com/callback", "read write"))
+	mock.ExpectQuery("select \\* from account where username = \\?").
+		WithArgs("testuser").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "password_hash"}).AddRow(1, "testuser", account.PasswordHash))
+	mock.ExpectExec("insert into oauth_authorization_code").
+		WithArgs(sqlmock.AnyArg(), 1, 1, "https://example.com/callback", "read:statuses", challenge, "S256", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	bodyBytes, err := json.Marshal(&AuthorizeRequest{
+		ResponseType:        "code",
+		ClientID:            "client",
+		RedirectURI:         "https://example.com/callback",
+		Scope:               "read:statuses",
+		State:               "xyz",
+		CodeChallenge:       challenge,
+		CodeChallengeMethod: "S256",
+		Username:            "testuser",
+		Password:            "securepassword",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := http.NewRequest(http.MethodPost, "/v1/oauth/authorize", bytes.NewReader(bodyBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	h.Authorize(w, r)
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	location, err := url.Parse(w.Header().Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := location.Query().Get("code")
+	assert.NotEmpty(t, code)
+	assert.Equal(t, "xyz", location.Query().Get("state"))
+
+	tests := []struct {
+		name        string
+		clientID    string
+		redirectURI string
+		verifier    string
+		wantCode    int
+	}{
+		// 検証に失敗したリクエストではコードを消費せず、正しいリクエストで交換できる
+		{name: "wrong code verifier", clientID: "client", redirectURI: "https://example.com/callback", verifier: "wrong", wantCode: http.StatusBadRequest},
+		{name: "wrong client", clientID: "other", redirectURI: "https://example.com/callback", verifier: verifier, wantCode: http.StatusBadRequest},
+		{name: "wrong redirect uri", clientID: "client", redirectURI: "https://example.com/other", verifier: verifier, wantCode: http.StatusBadRequest},
+		{name: "successfully exchange code", clientID: "client", redirectURI: "https://example.com/callback", verifier: verifier, wantCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applicationID := 1
+			if tt.clientID != "client" {
+				applicationID = 2
+			}
+			mock.ExpectQuery("select \\* from application where client_id = \\?").
+				WithArgs(tt.clientID).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "client_id", "client_secret", "redirect_uri", "scopes"}).
+					AddRow(applicationID, tt.clientID, "secret", tt.redirectURI, "read write"))
+			mock.ExpectBegin()
+			mock.ExpectQuery("select \\* from oauth_authorization_code where code = \\? for update").
+				WithArgs(code).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "code", "application_id", "account_id", "redirect_uri", "scopes", "code_challenge", "code_challenge_method", "expires_at"}).
+					AddRow(1, code, 1, 1, "https://example.com/callback", "read:statuses", challenge, "S256", time.Now().Add(time.Minute)))
+			if tt.wantCode == http.StatusOK {
+				mock.ExpectExec("delete from oauth_authorization_code where id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectExec("insert into access_token").
+					WithArgs(1, 1, sqlmock.AnyArg(), "read:statuses", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mock.ExpectRollback()
+			}
+
+			bodyBytes, err := json.Marshal(&TokenRequest{
+				GrantType:    "authorization_code",
+				ClientID:     tt.clientID,
+				Code:         code,
+				RedirectURI:  tt.redirectURI,
+				CodeVerifier: tt.verifier,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, err := http.NewRequest(http.MethodPost, "/v1/oauth/token", bytes.NewReader(bodyBytes))
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := httptest.NewRecorder()
+			h.Token(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode == http.StatusOK {
+				var resp TokenResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, "read:statuses", resp.Scope)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRevokeHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	tests := []struct {
+		name     string
+		body     *RevokeRequest
+		mockFunc func()
+		wantCode int
+	}{
+		{
+			name: "successfully revoke token",
+			body: &RevokeRequest{Token: "token"},
+			mockFunc: func() {
+				mock.ExpectExec("delete from access_token where token = \\?").
+					WithArgs("token").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "missing token",
+			body:     &RevokeRequest{},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bodyBytes, err := json.Marshal(tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r, err := http.NewRequest(http.MethodPost, "/v1/oauth/revoke", bytes.NewReader(bodyBytes))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.mockFunc != nil {
+				tt.mockFunc()
+			}
+
+			w := httptest.NewRecorder()
+			h.Revoke(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
+
+func newMockHandler(db *sql.DB) *handler {
+	return &handler{
+		app: &app.App{
+			Dao: dao.NewWithDB(sqlx.NewDb(db, "sqlmock")),
+		},
+	}
+}
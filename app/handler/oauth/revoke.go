@@ -0,0 +1,41 @@
+package oauth
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/handler/httperror"
+)
+
+type RevokeRequest struct {
+	Token string `json:"token"`
+}
+
+// Handle request for `POST /v1/oauth/revoke`
+func (h *handler) Revoke(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req RevokeRequest
+	if err := decodeBody(r, &req, func(get func(string) string) {
+		req.Token = get("token")
+	}); err != nil {
+		oauthError(w, http.StatusBadRequest, errInvalidRequest, err.Error())
+		return
+	}
+	if req.Token == "" {
+		oauthError(w, http.StatusBadRequest, errInvalidRequest, "token is required")
+		return
+	}
+
+	// RFC 7009: an unknown token is not an error, so the response is always empty
+	if err := h.app.Dao.Token().Delete(ctx, req.Token); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(struct{}{}); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
@@ -0,0 +1,25 @@
+package oauth
+
+import (
+	"net/http"
+
+	"yatter-backend-go/app/app"
+
+	"github.com/go-chi/chi"
+)
+
+type handler struct {
+	app *app.App
+}
+
+// Create Handler for `/v1/oauth/`
+func NewRouter(app *app.App) http.Handler {
+	r := chi.NewRouter()
+
+	h := &handler{app: app}
+	r.Post("/authorize", h.Authorize)
+	r.Post("/token", h.Token)
+	r.Post("/revoke", h.Revoke)
+
+	return r
+}
@@ -0,0 +1,209 @@
+package oauth
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"yatter-backend-go/app/config"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+const (
+	grantTypePassword          = "password"
+	grantTypeAuthorizationCode = "authorization_code"
+)
+
+type TokenRequest struct {
+	GrantType    string `json:"grant_type"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Scope        string `json:"scope"`
+
+	// password grant
+	Username string `json:"username"`
+	Password string `json:"password"`
+
+	// authorization_code grant
+	Code         string `json:"code"`
+	RedirectURI  string `json:"redirect_uri"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	Scope       string `json:"scope"`
+	ExpiresIn   int64  `json:"expires_in"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
+// Handle request for `POST /v1/oauth/token`
+func (h *handler) Token(w http.ResponseWriter, r *http.Request) {
+	var req TokenRequest
+	if err := decodeBody(r, &req, func(get func(string) string) {
+		req.GrantType = get("grant_type")
+		req.ClientID = get("client_id")
+		req.ClientSecret = get("client_secret")
+		req.Scope = get("scope")
+		req.Username = get("username")
+		req.Password = get("password")
+		req.Code = get("code")
+		req.RedirectURI = get("redirect_uri")
+		req.CodeVerifier = get("code_verifier")
+	}); err != nil {
+		oauthError(w, http.StatusBadRequest, errInvalidRequest, err.Error())
+		return
+	}
+
+	switch req.GrantType {
+	case grantTypePassword:
+		h.passwordGrant(w, r, &req)
+	case grantTypeAuthorizationCode:
+		h.authorizationCodeGrant(w, r, &req)
+	default:
+		oauthError(w, http.StatusBadRequest, errUnsupportedGrantType, "")
+	}
+}
+
+func (h *handler) passwordGrant(w http.ResponseWriter, r *http.Request, req *TokenRequest) {
+	ctx := r.Context()
+
+	if req.Username == "" || req.Password == "" {
+		oauthError(w, http.StatusBadRequest, errInvalidRequest, "username and password are required")
+		return
+	}
+
+	// The client is optional for the password grant, but must be valid when given
+	var application *object.Application
+	allowedScopes := object.FullScopes
+	if req.ClientID != "" {
+		var err error
+		application, err = h.app.Dao.Application().RetrieveByClientID(ctx, req.ClientID)
+		if err != nil && err != sql.ErrNoRows {
+			httperror.InternalServerError(w, err)
+			return
+		}
+		if application == nil || !secretMatches(application.ClientSecret, req.ClientSecret) {
+			oauthError(w, http.StatusUnauthorized, errInvalidClient, "")
+			return
+		}
+		allowedScopes = application.Scopes
+	}
+
+	scopes := req.Scope
+	if scopes == "" {
+		scopes = allowedScopes
+	}
+	if _, ok := object.ParseScopes(scopes); !ok || !object.ScopesSubset(scopes, allowedScopes) {
+		oauthError(w, http.StatusBadRequest, errInvalidScope, "")
+		return
+	}
+
+	account, err := h.app.Dao.Account().Retrieve(ctx, req.Username)
+	if err != nil && err != sql.ErrNoRows {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if account == nil || !account.CheckPassword(req.Password) {
+		oauthError(w, http.StatusBadRequest, errInvalidGrant, "invalid username or password")
+		return
+	}
+
+	h.issueToken(w, r, account.ID, application, scopes)
+}
+
+func (h *handler) authorizationCodeGrant(w http.ResponseWriter, r *http.Request, req *TokenRequest) {
+	ctx := r.Context()
+
+	if req.ClientID == "" || req.Code == "" {
+		oauthError(w, http.StatusBadRequest, errInvalidRequest, "client_id and code are required")
+		return
+	}
+
+	application, err := h.app.Dao.Application().RetrieveByClientID(ctx, req.ClientID)
+	if err != nil && err != sql.ErrNoRows {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if application == nil || (req.ClientSecret != "" && !secretMatches(application.ClientSecret, req.ClientSecret)) {
+		oauthError(w, http.StatusUnauthorized, errInvalidClient, "")
+		return
+	}
+
+	// The code is checked before it is deleted, so a wrong request does not burn it for its owner
+	code, err := h.app.Dao.AuthorizationCode().Consume(ctx, req.Code, func(code *object.AuthorizationCode) error {
+		// Public clients cannot keep a secret, so they have to prove possession with PKCE
+		if req.ClientSecret == "" && code.CodeChallenge == "" {
+			return &grantError{http.StatusUnauthorized, errInvalidClient, "client_secret or PKCE is required"}
+		}
+		if code.ApplicationID != application.ID || code.RedirectURI != req.RedirectURI || code.IsExpired(time.Now()) {
+			return &grantError{http.StatusBadRequest, errInvalidGrant, ""}
+		}
+		if !code.VerifyCodeVerifier(req.CodeVerifier) {
+			return &grantError{http.StatusBadRequest, errInvalidGrant, "code_verifier does not match"}
+		}
+		return nil
+	})
+	if err != nil {
+		if ge, ok := err.(*grantError); ok {
+			oauthError(w, ge.status, ge.name, ge.description)
+			return
+		}
+		if err == sql.ErrNoRows {
+			oauthError(w, http.StatusBadRequest, errInvalidGrant, "unknown or used code")
+			return
+		}
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	h.issueToken(w, r, code.AccountID, application, code.Scopes)
+}
+
+func (h *handler) issueToken(w http.ResponseWriter, r *http.Request, accountID object.AccountID, application *object.Application, scopes string) {
+	lifetime := config.TokenLifetime()
+	token, err := object.NewToken(accountID, scopes, lifetime)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if application != nil {
+		token.ApplicationID = &application.ID
+	}
+	if err := h.app.Dao.Token().Create(r.Context(), token); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(TokenResponse{
+		AccessToken: token.AccessToken,
+		TokenType:   "Bearer",
+		Scope:       token.Scopes,
+		ExpiresIn:   int64(lifetime.Seconds()),
+		CreatedAt:   token.CreateAt.Unix(),
+	}); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
+
+// An OAuth error found while checking the grant
+type grantError struct {
+	status      int
+	name        string
+	description string
+}
+
+func (e *grantError) Error() string {
+	return e.name + ": " + e.description
+}
+
+func secretMatches(expected, given string) bool {
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(given)) == 1
+}
@@ -0,0 +1,43 @@
+package oauth
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+)
+
+// Error codes defined in RFC 6749 section 5.2
+const (
+	errInvalidRequest          = "invalid_request"
+	errInvalidClient           = "invalid_client"
+	errInvalidGrant            = "invalid_grant"
+	errInvalidScope            = "invalid_scope"
+	errAccessDenied            = "access_denied"
+	errUnsupportedGrantType    = "unsupported_grant_type"
+	errUnsupportedResponseType = "unsupported_response_type"
+)
+
+type errorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// Response with OAuth error body
+func oauthError(w http.ResponseWriter, code int, name, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(errorResponse{Error: name, ErrorDescription: description})
+}
+
+// Decode the request body which may be either JSON or form encoded
+func decodeBody(r *http.Request, v interface{}, fromForm func(get func(string) string)) error {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data" {
+		if err := r.ParseForm(); err != nil {
+			return err
+		}
+		fromForm(r.PostForm.Get)
+		return nil
+	}
+	return json.NewDecoder(r.Body).Decode(v)
+}
@@ -0,0 +1,29 @@
+package polls
+
+import (
+	"encoding/json"
+	"net/http"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/presenter"
+)
+
+// Handle request for `GET /v1/polls/{id}`
+func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	poll, ok := h.retrieveVisible(w, r)
+	if !ok {
+		return
+	}
+
+	if err := presenter.Poll(ctx, h.app.Dao, auth.AccountOf(r), poll); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(poll); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
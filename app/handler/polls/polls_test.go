@@ -0,0 +1,321 @@
+package polls
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/testutil"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-chi/chi"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var pollColumns = []string{"id", "account_id", "status_id", "expires_at", "expired", "multiple", "hide_totals", "votes_count", "voters_count"}
+
+func expectPoll(mock sqlmock.Sqlmock, expiresAt time.Time, hideTotals bool, votes ...uint64) {
+	mock.ExpectQuery("select \\* from poll where id = \\?").
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows(pollColumns).AddRow(3, 1, 1, expiresAt, false, false, hideTotals, votes[0]+votes[1], votes[0]+votes[1]))
+	mock.ExpectQuery("select \\* from poll_option where poll_id in \\(\\?\\) order by poll_id, position").
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "poll_id", "position", "title", "votes_count"}).
+			AddRow(1, 3, 0, "yes", votes[0]).
+			AddRow(2, 3, 1, "no", votes[1]))
+}
+
+func expectStatus(mock sqlmock.Sqlmock, visibility string) {
+	mock.ExpectQuery("select \\* from status where id = \\?").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility", "poll_id"}).AddRow(1, 1, "test post", visibility, 3))
+}
+
+// Expect the check whether the voter 2 is blocking the author 1
+func expectBlocked(mock sqlmock.Sqlmock, blocked bool) {
+	rows := sqlmock.NewRows([]string{"target_account_id"})
+	if blocked {
+		rows.AddRow(1)
+	}
+	mock.ExpectQuery("select target_account_id from block where account_id = \\? and target_account_id in \\(\\?\\)").
+		WithArgs(2, 1).
+		WillReturnRows(rows)
+}
+
+func TestGetHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	tests := []struct {
+		name        string
+		id          string
+		mockFunc    func()
+		wantCode    int
+		wantExpired bool
+		wantHidden  bool
+	}{
+		{
+			name: "successfully get poll",
+			id:   "3",
+			mockFunc: func() {
+				expectPoll(mock, time.Now().Add(time.Hour), false, 2, 1)
+				expectStatus(mock, "public")
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "hide totals until the poll ends",
+			id:   "3",
+			mockFunc: func() {
+				expectPoll(mock, time.Now().Add(time.Hour), true, 2, 1)
+				expectStatus(mock, "public")
+			},
+			wantCode:   http.StatusOK,
+			wantHidden: true,
+		},
+		{
+			name: "expired before closed by worker",
+			id:   "3",
+			mockFunc: func() {
+				expectPoll(mock, time.Now().Add(-time.Minute), true, 2, 1)
+				expectStatus(mock, "public")
+			},
+			wantCode:    http.StatusOK,
+			wantExpired: true,
+		},
+		{
+			name: "poll on private status",
+			id:   "3",
+			mockFunc: func() {
+				expectPoll(mock, time.Now().Add(time.Hour), false, 2, 1)
+				expectStatus(mock, "private")
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "not found",
+			id:   "3",
+			mockFunc: func() {
+				mock.ExpectQuery("select \\* from poll where id = \\?").
+					WithArgs(3).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodGet, "/v1/polls/"+tt.id, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r = setChiURLParam(r, "id", tt.id)
+			tt.mockFunc()
+			h.Get(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode == http.StatusOK {
+				var resp object.Poll
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, uint64(3), resp.ID)
+				assert.Equal(t, tt.wantExpired, resp.Expired)
+				assert.Equal(t, []int{}, resp.OwnVotes)
+				if assert.Len(t, resp.Options, 2) {
+					assert.Equal(t, "yes", resp.Options[0].Title)
+					if tt.wantHidden {
+						assert.Nil(t, resp.Options[0].VotesCount)
+					} else if assert.NotNil(t, resp.Options[0].VotesCount) {
+						assert.Equal(t, uint64(2), *resp.Options[0].VotesCount)
+					}
+				}
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestVoteHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	expectLock := func(expiresAt time.Time) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("select \\* from poll where id = \\? for update").
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows(pollColumns).AddRow(3, 1, 1, expiresAt, false, false, false, 0, 0))
+	}
+
+	tests := []struct {
+		name     string
+		body     *VoteRequest
+		isAuth   bool
+		mockFunc func()
+		wantCode int
+	}{
+		{
+			name:   "successfully vote",
+			body:   &VoteRequest{Choices: []int{1}},
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 2, "voter")
+				expectPoll(mock, time.Now().Add(time.Hour), false, 0, 0)
+				expectStatus(mock, "public")
+				expectBlocked(mock, false)
+				expectLock(time.Now().Add(time.Hour))
+				mock.ExpectQuery("select exists \\(select 1 from poll_vote where poll_id = \\? and account_id = \\?\\)").
+					WithArgs(3, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectExec("insert into poll_vote \\(poll_id, account_id, choice\\) values \\(\\?, \\?, \\?\\)").
+					WithArgs(3, 2, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("update poll_option set votes_count = votes_count \\+ 1 where poll_id = \\? and position = \\?").
+					WithArgs(3, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("update poll set votes_count = votes_count \\+ \\?, voters_count = voters_count \\+ 1 where id = \\?").
+					WithArgs(1, 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				expectPoll(mock, time.Now().Add(time.Hour), false, 0, 1)
+				mock.ExpectQuery("select poll_id, choice from poll_vote where account_id = \\? and poll_id in \\(\\?\\) order by poll_id, choice").
+					WithArgs(2, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"poll_id", "choice"}).AddRow(3, 1))
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "already voted",
+			body:   &VoteRequest{Choices: []int{0}},
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 2, "voter")
+				expectPoll(mock, time.Now().Add(time.Hour), false, 0, 1)
+				expectStatus(mock, "public")
+				expectBlocked(mock, false)
+				expectLock(time.Now().Add(time.Hour))
+				mock.ExpectQuery("select exists \\(select 1 from poll_vote where poll_id = \\? and account_id = \\?\\)").
+					WithArgs(3, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "poll has ended",
+			body:   &VoteRequest{Choices: []int{0}},
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 2, "voter")
+				expectPoll(mock, time.Now().Add(-time.Minute), false, 0, 0)
+				expectStatus(mock, "public")
+				expectBlocked(mock, false)
+				expectLock(time.Now().Add(-time.Minute))
+				mock.ExpectRollback()
+			},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "multiple choices on single choice poll",
+			body:   &VoteRequest{Choices: []int{0, 1}},
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 2, "voter")
+				expectPoll(mock, time.Now().Add(time.Hour), false, 0, 0)
+				expectStatus(mock, "public")
+				expectBlocked(mock, false)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:   "choice out of range",
+			body:   &VoteRequest{Choices: []int{2}},
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 2, "voter")
+				expectPoll(mock, time.Now().Add(time.Hour), false, 0, 0)
+				expectStatus(mock, "public")
+				expectBlocked(mock, false)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:   "poll of blocked account",
+			body:   &VoteRequest{Choices: []int{0}},
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 2, "voter")
+				expectPoll(mock, time.Now().Add(time.Hour), false, 0, 0)
+				expectStatus(mock, "public")
+				expectBlocked(mock, true)
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "unauthorized",
+			body:     &VoteRequest{Choices: []int{0}},
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bodyBytes, err := json.Marshal(tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodPost, "/v1/polls/3/votes", bytes.NewReader(bodyBytes))
+			if err != nil {
+				t.Fatal(err)
+			}
+			r = setChiURLParam(r, "id", "3")
+			if tt.isAuth {
+				testutil.SetAuth(r)
+			}
+			if tt.mockFunc != nil {
+				tt.mockFunc()
+			}
+			auth.Middleware(h.app)(http.HandlerFunc(h.Vote)).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode == http.StatusOK {
+				var resp object.Poll
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+				assert.True(t, resp.Voted)
+				assert.Equal(t, tt.body.Choices, resp.OwnVotes)
+				assert.Equal(t, uint64(1), resp.VotesCount)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func newMockHandler(db *sql.DB) *handler {
+	return &handler{
+		app: &app.App{
+			Dao: dao.NewWithDB(sqlx.NewDb(db, "sqlmock")),
+		},
+	}
+}
+
+func setChiURLParam(r *http.Request, key, value string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
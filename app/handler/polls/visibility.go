@@ -0,0 +1,49 @@
+package polls
+
+import (
+	"database/sql"
+	"net/http"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+	"yatter-backend-go/app/handler/visibility"
+)
+
+// Retrieve the poll of path parameter `id`.
+// Polls on statuses the viewer may not see, by the same rules as the statuses, are treated as not found.
+func (h *handler) retrieveVisible(w http.ResponseWriter, r *http.Request) (*object.Poll, bool) {
+	ctx := r.Context()
+
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return nil, false
+	}
+
+	poll, err := h.app.Dao.Poll().Retrieve(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			httperror.NotFound(w, id)
+			return nil, false
+		}
+		httperror.InternalServerError(w, err)
+		return nil, false
+	}
+
+	status, err := h.app.Dao.Status().Retrieve(ctx, poll.StatusID)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return nil, false
+	}
+	visible, err := visibility.Filter(ctx, h.app.Dao, auth.AccountOf(r), []*object.Status{status})
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return nil, false
+	}
+	if len(visible) == 0 {
+		httperror.NotFound(w, id)
+		return nil, false
+	}
+	return poll, true
+}
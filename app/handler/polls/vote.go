@@ -0,0 +1,69 @@
+package polls
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+	"yatter-backend-go/app/domain/repository"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/presenter"
+)
+
+type VoteRequest struct {
+	// Indices of the options to vote for
+	Choices []int
+}
+
+// Handle request for `POST /v1/polls/{id}/votes`
+func (h *handler) Vote(w http.ResponseWriter, r *http.Request) {
+	account := auth.AccountOf(r)
+	if account == nil {
+		httperror.Error(w, http.StatusUnauthorized)
+		return
+	}
+	ctx := r.Context()
+
+	poll, ok := h.retrieveVisible(w, r)
+	if !ok {
+		return
+	}
+
+	var req VoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if err := poll.ValidateChoices(req.Choices); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	if err := h.app.Dao.Poll().Vote(ctx, poll.ID, account.ID, req.Choices, time.Now()); err != nil {
+		switch err {
+		case repository.ErrPollExpired:
+			httperror.UnprocessableEntity(w, "The poll has already ended")
+		case repository.ErrAlreadyVoted:
+			httperror.UnprocessableEntity(w, "You have already voted on this poll")
+		default:
+			httperror.InternalServerError(w, err)
+		}
+		return
+	}
+
+	// 集計結果を反映するため取り直す
+	poll, err := h.app.Dao.Poll().Retrieve(ctx, poll.ID)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if err := presenter.Poll(ctx, h.app.Dao, account, poll); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(poll); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
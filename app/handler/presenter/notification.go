@@ -0,0 +1,62 @@
+package presenter
+
+import (
+	"context"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+)
+
+// Notification fills in the account and the status of the notification
+func Notification(ctx context.Context, d dao.Dao, viewer *object.Account, notification *object.Notification) error {
+	return Notifications(ctx, d, viewer, []*object.Notification{notification})
+}
+
+// Notifications fills in the accounts and the statuses of the notifications.
+// The statuses are filled in for viewer, who is the notified account.
+func Notifications(ctx context.Context, d dao.Dao, viewer *object.Account, notifications []*object.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	accountIDs := make([]object.AccountID, 0, len(notifications))
+	var statusIDs []uint64
+	seen := make(map[object.AccountID]bool)
+	for _, notification := range notifications {
+		if !seen[notification.FromAccountID] {
+			seen[notification.FromAccountID] = true
+			accountIDs = append(accountIDs, notification.FromAccountID)
+		}
+		if notification.StatusID != nil {
+			statusIDs = append(statusIDs, *notification.StatusID)
+		}
+	}
+
+	accounts, err := d.Account().RetrieveByIDs(ctx, accountIDs)
+	if err != nil {
+		return err
+	}
+	accountByID := make(map[object.AccountID]*object.Account, len(accounts))
+	for _, account := range accounts {
+		accountByID[account.ID] = account
+	}
+
+	statuses, err := d.Status().RetrieveByIDs(ctx, statusIDs)
+	if err != nil {
+		return err
+	}
+	if err := Statuses(ctx, d, viewer, statuses); err != nil {
+		return err
+	}
+	statusByID := make(map[uint64]*object.Status, len(statuses))
+	for _, status := range statuses {
+		statusByID[status.ID] = status
+	}
+
+	for _, notification := range notifications {
+		notification.Account = accountByID[notification.FromAccountID]
+		if notification.StatusID != nil {
+			notification.Status = statusByID[*notification.StatusID]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package presenter
+
+import (
+	"context"
+	"time"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+)
+
+// Poll fills in the viewer's votes of the poll and hides the totals the viewer may not see yet
+func Poll(ctx context.Context, d dao.Dao, viewer *object.Account, poll *object.Poll) error {
+	return Polls(ctx, d, viewer, []*object.Poll{poll})
+}
+
+// Polls fills in the viewer's votes of the polls and hides the totals the viewer may not see yet.
+// viewer may be nil for unauthenticated requests.
+func Polls(ctx context.Context, d dao.Dao, viewer *object.Account, polls []*object.Poll) error {
+	if len(polls) == 0 {
+		return nil
+	}
+
+	ownVotes := make(map[uint64][]int)
+	if viewer != nil {
+		ids := make([]uint64, len(polls))
+		for i, poll := range polls {
+			ids[i] = poll.ID
+		}
+		var err error
+		ownVotes, err = d.Poll().OwnVotes(ctx, viewer.ID, ids)
+		if err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	for _, poll := range polls {
+		// ワーカーが締め切る前でも期限を過ぎていれば終了として扱う
+		poll.Expired = poll.IsExpired(now)
+		poll.OwnVotes = ownVotes[poll.ID]
+		if poll.OwnVotes == nil {
+			poll.OwnVotes = []int{}
+		}
+		poll.Voted = len(poll.OwnVotes) > 0
+		if poll.HideTotals && !poll.Expired && (viewer == nil || viewer.ID != poll.AccountID) {
+			for _, option := range poll.Options {
+				option.VotesCount = nil
+			}
+		}
+	}
+	return nil
+}
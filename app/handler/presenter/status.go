@@ -0,0 +1,159 @@
+package presenter
+
+import (
+	"context"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+)
+
+// Status fills in the author account, media attachments, mentions, tags, poll and viewer's state of the status
+func Status(ctx context.Context, d dao.Dao, viewer *object.Account, status *object.Status) error {
+	return Statuses(ctx, d, viewer, []*object.Status{status})
+}
+
+// Statuses fills in the author accounts, media attachments, mentions, tags, polls and viewer's state of the statuses.
+// Each of them is loaded with one query regardless of the number of statuses.
+// viewer may be nil for unauthenticated requests.
+func Statuses(ctx context.Context, d dao.Dao, viewer *object.Account, statuses []*object.Status) error {
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	// ブーストされた元の投稿も一緒に読み込む
+	var reblogOfIDs []uint64
+	for _, status := range statuses {
+		if status.ReblogOfID != nil {
+			reblogOfIDs = append(reblogOfIDs, *status.ReblogOfID)
+		}
+	}
+	originals, err := d.Status().RetrieveByIDs(ctx, reblogOfIDs)
+	if err != nil {
+		return err
+	}
+	originalByID := make(map[uint64]*object.Status, len(originals))
+	for _, original := range originals {
+		originalByID[original.ID] = original
+	}
+
+	if err := fill(ctx, d, viewer, append(append([]*object.Status{}, statuses...), originals...)); err != nil {
+		return err
+	}
+	for _, status := range statuses {
+		if status.ReblogOfID != nil {
+			status.Reblog = originalByID[*status.ReblogOfID]
+		}
+	}
+	return nil
+}
+
+func fill(ctx context.Context, d dao.Dao, viewer *object.Account, statuses []*object.Status) error {
+
+	accountIDs := make([]object.AccountID, 0, len(statuses))
+	statusIDs := make([]uint64, 0, len(statuses))
+	seen := make(map[object.AccountID]bool)
+	for _, status := range statuses {
+		if !seen[status.AccountId] {
+			seen[status.AccountId] = true
+			accountIDs = append(accountIDs, status.AccountId)
+		}
+		statusIDs = append(statusIDs, status.ID)
+	}
+
+	accounts, err := d.Account().RetrieveByIDs(ctx, accountIDs)
+	if err != nil {
+		return err
+	}
+	accountByID := make(map[object.AccountID]*object.Account, len(accounts))
+	for _, account := range accounts {
+		accountByID[account.ID] = account
+	}
+
+	attachments, err := d.Attachment().RetrieveByStatusIDs(ctx, statusIDs)
+	if err != nil {
+		return err
+	}
+	attachmentsByStatusID := make(map[uint64][]*object.Attachment)
+	for _, attachment := range attachments {
+		attachmentsByStatusID[*attachment.StatusID] = append(attachmentsByStatusID[*attachment.StatusID], attachment)
+	}
+
+	mentions, err := d.Mention().RetrieveByStatusIDs(ctx, statusIDs)
+	if err != nil {
+		return err
+	}
+	mentionsByStatusID := make(map[uint64][]*object.Mention)
+	for _, mention := range mentions {
+		mention.URL = object.AccountURL(mention.Username)
+		mentionsByStatusID[mention.StatusID] = append(mentionsByStatusID[mention.StatusID], mention)
+	}
+
+	tags, err := d.Tag().RetrieveByStatusIDs(ctx, statusIDs)
+	if err != nil {
+		return err
+	}
+	tagsByStatusID := make(map[uint64][]*object.Tag)
+	for _, tag := range tags {
+		tag.URL = object.TagURL(tag.Name)
+		tagsByStatusID[tag.StatusID] = append(tagsByStatusID[tag.StatusID], tag)
+	}
+
+	favourited := make(map[uint64]bool)
+	reblogged := make(map[uint64]bool)
+	if viewer != nil {
+		ids, err := d.Favourite().FavouritedStatusIDs(ctx, viewer.ID, statusIDs)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			favourited[id] = true
+		}
+
+		ids, err = d.Status().RebloggedStatusIDs(ctx, viewer.ID, statusIDs)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			reblogged[id] = true
+		}
+	}
+
+	var pollIDs []uint64
+	for _, status := range statuses {
+		if status.PollID != nil {
+			pollIDs = append(pollIDs, *status.PollID)
+		}
+	}
+	polls, err := d.Poll().RetrieveByIDs(ctx, pollIDs)
+	if err != nil {
+		return err
+	}
+	if err := Polls(ctx, d, viewer, polls); err != nil {
+		return err
+	}
+	pollByID := make(map[uint64]*object.Poll, len(polls))
+	for _, poll := range polls {
+		pollByID[poll.ID] = poll
+	}
+
+	for _, status := range statuses {
+		status.Account = accountByID[status.AccountId]
+		if status.PollID != nil {
+			status.Poll = pollByID[*status.PollID]
+		}
+		status.Favourited = favourited[status.ID]
+		status.Reblogged = reblogged[status.ID]
+		status.MediaAttachments = attachmentsByStatusID[status.ID]
+		if status.MediaAttachments == nil {
+			status.MediaAttachments = []*object.Attachment{}
+		}
+		status.Mentions = mentionsByStatusID[status.ID]
+		if status.Mentions == nil {
+			status.Mentions = []*object.Mention{}
+		}
+		status.Tags = tagsByStatusID[status.ID]
+		if status.Tags == nil {
+			status.Tags = []*object.Tag{}
+		}
+	}
+	return nil
+}
@@ -34,6 +34,16 @@ func UsernameOf(r *http.Request) (string, error) {
 	return username, nil
 }
 
+// Read path parameter `hashtag`
+func HashtagOf(r *http.Request) (string, error) {
+	hashtag := chi.URLParam(r, "hashtag")
+
+	if hashtag == "" {
+		return "", errors.Errorf("hashtag was not presence")
+	}
+	return hashtag, nil
+}
+
 func ParseQueries(r *http.Request) (only_media, max_id, since_id, limit *uint64, err error) {
 	only_media, err = ParseQueryPointer(r.URL.Query().Get("only_media"))
 	if err != nil {
@@ -6,9 +6,21 @@ import (
 
 	"yatter-backend-go/app/app"
 	"yatter-backend-go/app/handler/accounts"
+	"yatter-backend-go/app/handler/apps"
+	"yatter-backend-go/app/handler/blocks"
+	"yatter-backend-go/app/handler/followrequests"
 	"yatter-backend-go/app/handler/health"
+	"yatter-backend-go/app/handler/media"
+	"yatter-backend-go/app/handler/mutes"
+	"yatter-backend-go/app/handler/notifications"
+	"yatter-backend-go/app/handler/oauth"
+	"yatter-backend-go/app/handler/polls"
+	"yatter-backend-go/app/handler/scheduledstatuses"
+	"yatter-backend-go/app/handler/search"
 	"yatter-backend-go/app/handler/statuses"
 	"yatter-backend-go/app/handler/timelines"
+	"yatter-backend-go/app/handler/trends"
+	"yatter-backend-go/app/storage"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
@@ -31,9 +43,25 @@ func NewRouter(app *app.App) http.Handler {
 	r.Use(middleware.Timeout(60 * time.Second))
 
 	r.Mount("/v1/accounts", accounts.NewRouter(app))
+	r.Mount("/v1/apps", apps.NewRouter(app))
+	r.Mount("/v1/blocks", blocks.NewRouter(app))
+	r.Mount("/v1/follow_requests", followrequests.NewRouter(app))
 	r.Mount("/v1/health", health.NewRouter())
+	r.Mount("/v1/media", media.NewRouter(app))
+	r.Mount("/v1/mutes", mutes.NewRouter(app))
+	r.Mount("/v1/notifications", notifications.NewRouter(app))
+	r.Mount("/v1/oauth", oauth.NewRouter(app))
+	r.Mount("/v1/polls", polls.NewRouter(app))
+	r.Mount("/v1/scheduled_statuses", scheduledstatuses.NewRouter(app))
 	r.Mount("/v1/statuses", statuses.NewRouter(app))
 	r.Mount("/v1/timelines", timelines.NewRouter(app))
+	r.Mount("/v1/trends", trends.NewRouter(app))
+	r.Mount("/v2/search", search.NewRouter(app))
+
+	// Uploaded files are served by ourselves only when stored on the local filesystem
+	if local, ok := app.Storage.(*storage.Local); ok {
+		r.Mount(local.MountPath(), local)
+	}
 
 	return r
 }
@@ -0,0 +1,26 @@
+package scheduledstatuses
+
+import (
+	"encoding/json"
+	"net/http"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Handle request for `DELETE /v1/scheduled_statuses/{id}`
+func (h *handler) Delete(w http.ResponseWriter, r *http.Request) {
+	scheduled, ok := h.retrieveOwn(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.app.Dao.ScheduledStatus().Delete(r.Context(), scheduled.ID); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(struct{}{}); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
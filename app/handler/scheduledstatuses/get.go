@@ -0,0 +1,63 @@
+package scheduledstatuses
+
+import (
+	"encoding/json"
+	"net/http"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Handle request for `GET /v1/scheduled_statuses`
+func (h *handler) List(w http.ResponseWriter, r *http.Request) {
+	account := auth.AccountOf(r)
+	if account == nil {
+		httperror.Error(w, http.StatusUnauthorized)
+		return
+	}
+	ctx := r.Context()
+
+	limit, err := request.ParseLimitQuery(r.URL.Query().Get("limit"))
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	scheduled, err := h.app.Dao.ScheduledStatus().RetrieveByAccountID(ctx, account.ID, limit)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if scheduled == nil {
+		scheduled = []*object.ScheduledStatus{}
+	}
+	if err := h.fillMedia(ctx, scheduled); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(scheduled); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
+
+// Handle request for `GET /v1/scheduled_statuses/{id}`
+func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
+	scheduled, ok := h.retrieveOwn(w, r)
+	if !ok {
+		return
+	}
+	if err := h.fillMedia(r.Context(), []*object.ScheduledStatus{scheduled}); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(scheduled); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
@@ -0,0 +1,28 @@
+package scheduledstatuses
+
+import (
+	"net/http"
+
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+
+	"github.com/go-chi/chi"
+)
+
+type handler struct {
+	app *app.App
+}
+
+// Create Handler for `/v1/scheduled_statuses/`
+func NewRouter(app *app.App) http.Handler {
+	r := chi.NewRouter()
+
+	h := &handler{app: app}
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeReadStatuses)).Get("/", h.List)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeReadStatuses)).Get("/{id}", h.Get)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeWriteStatuses)).Put("/{id}", h.Update)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeWriteStatuses)).Delete("/{id}", h.Delete)
+
+	return r
+}
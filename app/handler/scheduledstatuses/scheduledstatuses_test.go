@@ -0,0 +1,246 @@
+package scheduledstatuses
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/testutil"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-chi/chi"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var scheduledColumns = []string{"id", "account_id", "scheduled_at", "params"}
+
+func TestListHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	scheduledAt := time.Now().Add(time.Hour)
+	testutil.ExpectAuth(mock, 1, "testuser")
+	mock.ExpectQuery("select \\* from scheduled_status where account_id = \\? order by scheduled_at, id limit \\?").
+		WithArgs(1, 40).
+		WillReturnRows(sqlmock.NewRows(scheduledColumns).
+			AddRow(7, 1, scheduledAt, `{"text":"with media","media_ids":[10],"visibility":"public"}`).
+			AddRow(8, 1, scheduledAt, `{"text":"later","visibility":"private"}`))
+	mock.ExpectQuery("select \\* from attachment where id in \\(\\?\\) order by id").
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "scheduled_status_id", "type", "url"}).AddRow(10, 1, 7, "image", "/media/a.png"))
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest(http.MethodGet, "/v1/scheduled_statuses", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.SetAuth(r)
+	auth.Middleware(h.app)(http.HandlerFunc(h.List)).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp []object.ScheduledStatus
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, resp, 2) {
+		assert.Equal(t, "with media", resp[0].Params.Text)
+		assert.Len(t, resp[0].MediaAttachments, 1)
+		assert.Equal(t, object.VisibilityPrivate, resp[1].Params.Visibility)
+		assert.Equal(t, []*object.Attachment{}, resp[1].MediaAttachments)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantCode int
+	}{
+		{
+			name: "successfully get scheduled status",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from scheduled_status where id = \\?").
+					WithArgs(7).
+					WillReturnRows(sqlmock.NewRows(scheduledColumns).AddRow(7, 1, time.Now().Add(time.Hour), `{"text":"test post"}`))
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "scheduled status of another account",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from scheduled_status where id = \\?").
+					WithArgs(7).
+					WillReturnRows(sqlmock.NewRows(scheduledColumns).AddRow(7, 2, time.Now().Add(time.Hour), `{"text":"test post"}`))
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "not found",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from scheduled_status where id = \\?").
+					WithArgs(7).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodGet, "/v1/scheduled_statuses/7", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r = setChiURLParam(r, "id", "7")
+			testutil.SetAuth(r)
+			tt.mockFunc()
+			auth.Middleware(h.app)(http.HandlerFunc(h.Get)).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdateHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	later := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name     string
+		body     *UpdateRequest
+		mockFunc func()
+		wantCode int
+	}{
+		{
+			name: "successfully reschedule",
+			body: &UpdateRequest{ScheduledAt: &later},
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from scheduled_status where id = \\?").
+					WithArgs(7).
+					WillReturnRows(sqlmock.NewRows(scheduledColumns).AddRow(7, 1, time.Now().Add(time.Hour), `{"text":"test post"}`))
+				mock.ExpectExec("update scheduled_status set scheduled_at = \\?, lease_token = null, lease_expires_at = null, failure = null where id = \\?").
+					WithArgs(sqlmock.AnyArg(), 7).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "reschedule too soon",
+			body: &UpdateRequest{ScheduledAt: newTime(time.Now())},
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from scheduled_status where id = \\?").
+					WithArgs(7).
+					WillReturnRows(sqlmock.NewRows(scheduledColumns).AddRow(7, 1, time.Now().Add(time.Hour), `{"text":"test post"}`))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "missing scheduled_at",
+			body: &UpdateRequest{},
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from scheduled_status where id = \\?").
+					WithArgs(7).
+					WillReturnRows(sqlmock.NewRows(scheduledColumns).AddRow(7, 1, time.Now().Add(time.Hour), `{"text":"test post"}`))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bodyBytes, err := json.Marshal(tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodPut, "/v1/scheduled_statuses/7", bytes.NewReader(bodyBytes))
+			if err != nil {
+				t.Fatal(err)
+			}
+			r = setChiURLParam(r, "id", "7")
+			testutil.SetAuth(r)
+			tt.mockFunc()
+			auth.Middleware(h.app)(http.HandlerFunc(h.Update)).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode == http.StatusOK {
+				var resp object.ScheduledStatus
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+				assert.True(t, later.Equal(resp.ScheduledAt.Time))
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDeleteHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	testutil.ExpectAuth(mock, 1, "testuser")
+	mock.ExpectQuery("select \\* from scheduled_status where id = \\?").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows(scheduledColumns).AddRow(7, 1, time.Now().Add(time.Hour), `{"text":"test post"}`))
+	mock.ExpectExec("delete from scheduled_status where id = \\?").
+		WithArgs(7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest(http.MethodDelete, "/v1/scheduled_statuses/7", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r = setChiURLParam(r, "id", "7")
+	testutil.SetAuth(r)
+	auth.Middleware(h.app)(http.HandlerFunc(h.Delete)).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func newMockHandler(db *sql.DB) *handler {
+	return &handler{
+		app: &app.App{
+			Dao: dao.NewWithDB(sqlx.NewDb(db, "sqlmock")),
+		},
+	}
+}
+
+func setChiURLParam(r *http.Request, key, value string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+func newTime(t time.Time) *time.Time {
+	return &t
+}
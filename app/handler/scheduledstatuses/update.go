@@ -0,0 +1,54 @@
+package scheduledstatuses
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/httperror"
+
+	"github.com/pkg/errors"
+)
+
+type UpdateRequest struct {
+	ScheduledAt *time.Time `json:"scheduled_at"`
+}
+
+// Handle request for `PUT /v1/scheduled_statuses/{id}`
+func (h *handler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	scheduled, ok := h.retrieveOwn(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if req.ScheduledAt == nil {
+		httperror.BadRequest(w, errors.New("scheduled_at is required"))
+		return
+	}
+	if err := scheduled.SetScheduledAt(*req.ScheduledAt, time.Now()); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	if err := h.app.Dao.ScheduledStatus().Reschedule(ctx, scheduled.ID, scheduled.ScheduledAt.Time); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if err := h.fillMedia(ctx, []*object.ScheduledStatus{scheduled}); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(scheduled); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
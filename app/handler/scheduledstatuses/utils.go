@@ -0,0 +1,68 @@
+package scheduledstatuses
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Retrieve the scheduled status of path parameter `id`.
+// Scheduled statuses of other accounts are treated as not found.
+func (h *handler) retrieveOwn(w http.ResponseWriter, r *http.Request) (*object.ScheduledStatus, bool) {
+	account := auth.AccountOf(r)
+	if account == nil {
+		httperror.Error(w, http.StatusUnauthorized)
+		return nil, false
+	}
+
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return nil, false
+	}
+
+	scheduled, err := h.app.Dao.ScheduledStatus().Retrieve(r.Context(), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			httperror.NotFound(w, id)
+			return nil, false
+		}
+		httperror.InternalServerError(w, err)
+		return nil, false
+	}
+	if scheduled.AccountID != account.ID {
+		httperror.NotFound(w, id)
+		return nil, false
+	}
+	return scheduled, true
+}
+
+// Fill in the media reserved for the scheduled statuses with one query
+func (h *handler) fillMedia(ctx context.Context, scheduled []*object.ScheduledStatus) error {
+	var ids []uint64
+	for _, s := range scheduled {
+		ids = append(ids, s.Params.MediaIDs...)
+	}
+	attachments, err := h.app.Dao.Attachment().RetrieveByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	attachmentByID := make(map[uint64]*object.Attachment, len(attachments))
+	for _, attachment := range attachments {
+		attachmentByID[attachment.ID] = attachment
+	}
+
+	for _, s := range scheduled {
+		s.MediaAttachments = []*object.Attachment{}
+		for _, id := range s.Params.MediaIDs {
+			if attachment, ok := attachmentByID[id]; ok {
+				s.MediaAttachments = append(s.MediaAttachments, attachment)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package search
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/presenter"
+	"yatter-backend-go/app/handler/request"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	typeAccounts = "accounts"
+	typeStatuses = "statuses"
+	typeHashtags = "hashtags"
+)
+
+type Response struct {
+	Accounts []*object.Account `json:"accounts"`
+	Statuses []*object.Status  `json:"statuses"`
+	Hashtags []*object.Tag     `json:"hashtags"`
+}
+
+// Handle request for `GET /v2/search`
+func (h *handler) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query := r.URL.Query()
+	q := strings.TrimSpace(query.Get("q"))
+	if q == "" {
+		httperror.BadRequest(w, errors.New("q is required"))
+		return
+	}
+	searchType := query.Get("type")
+	switch searchType {
+	case "", typeAccounts, typeStatuses, typeHashtags:
+	default:
+		httperror.BadRequest(w, errors.Errorf("unknown type %q", searchType))
+		return
+	}
+	offset, err := request.ParseQueryPointer(query.Get("offset"))
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	limit, err := request.ParseLimitQuery(query.Get("limit"))
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	resp := Response{
+		Accounts: []*object.Account{},
+		Statuses: []*object.Status{},
+		Hashtags: []*object.Tag{},
+	}
+	if searchType == "" || searchType == typeAccounts {
+		accounts, err := h.app.Search.Accounts(ctx, strings.TrimPrefix(q, "@"), offset, limit)
+		if err != nil {
+			httperror.InternalServerError(w, err)
+			return
+		}
+		if accounts != nil {
+			resp.Accounts = accounts
+		}
+	}
+	if searchType == "" || searchType == typeStatuses {
+		var viewerID *object.AccountID
+		if viewer := auth.AccountOf(r); viewer != nil {
+			viewerID = &viewer.ID
+		}
+		// ブロック・ミュートしているアカウントの投稿は検索で除かれる
+		statuses, err := h.app.Search.Statuses(ctx, viewerID, q, offset, limit)
+		if err != nil {
+			httperror.InternalServerError(w, err)
+			return
+		}
+		if err := presenter.Statuses(ctx, h.app.Dao, auth.AccountOf(r), statuses); err != nil {
+			httperror.InternalServerError(w, err)
+			return
+		}
+		if statuses != nil {
+			resp.Statuses = statuses
+		}
+	}
+	if searchType == "" || searchType == typeHashtags {
+		tags, err := h.app.Search.Tags(ctx, q, offset, limit)
+		if err != nil {
+			httperror.InternalServerError(w, err)
+			return
+		}
+		for _, tag := range tags {
+			tag.URL = object.TagURL(tag.Name)
+		}
+		if tags != nil {
+			resp.Hashtags = tags
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
@@ -0,0 +1,146 @@
+package search
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/testutil"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	expectAccounts := func(args ...driver.Value) {
+		mock.ExpectQuery("select \\* from account where username like \\? or display_name like \\? or match \\(username, display_name\\) against \\(\\?\\)" +
+			" order by username = \\? desc, username like \\? desc, display_name like \\? desc, match \\(username, display_name\\) against \\(\\?\\) desc, followers_count desc, id limit \\?").
+			WithArgs(args...).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "john"))
+	}
+	expectStatuses := func() {
+		mock.ExpectQuery("select \\* from status where match \\(text\\) against \\(\\?\\) and visibility = 'public' and reblog_of_id is null order by match \\(text\\) against \\(\\?\\) desc, id desc limit \\?").
+			WithArgs("john", "john", 40).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content"}))
+	}
+	expectTags := func(args ...driver.Value) {
+		mock.ExpectQuery("select \\* from tag where name like \\? order by name = \\? desc, name limit \\?").
+			WithArgs(args...).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "john"))
+	}
+
+	tests := []struct {
+		name         string
+		query        string
+		isAuth       bool
+		mockFunc     func()
+		wantCode     int
+		wantAccounts int
+		wantHashtags int
+	}{
+		{
+			name:  "all types",
+			query: "?q=john",
+			mockFunc: func() {
+				expectAccounts("john%", "%john%", "john", "john", "john%", "%john%", "john", 40)
+				expectStatuses()
+				expectTags("john%", "john", 40)
+			},
+			wantCode:     http.StatusOK,
+			wantAccounts: 1,
+			wantHashtags: 1,
+		},
+		{
+			name:  "accounts with pagination",
+			query: "?q=@jo_hn&type=accounts&offset=20&limit=10",
+			mockFunc: func() {
+				expectAccounts("jo\\_hn%", "%jo\\_hn%", "jo_hn", "jo_hn", "jo\\_hn%", "%jo\\_hn%", "jo_hn", 10, 20)
+			},
+			wantCode:     http.StatusOK,
+			wantAccounts: 1,
+		},
+		{
+			name:   "statuses without blocked and muted accounts",
+			query:  "?q=john&type=statuses",
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where match \\(text\\) against \\(\\?\\) and visibility = 'public' and reblog_of_id is null and account_id not in \\(select target_account_id from block where account_id = \\? union select target_account_id from mute where account_id = \\? and .+\\) order by match \\(text\\) against \\(\\?\\) desc, id desc limit \\?").
+					WithArgs("john", 1, 1, sqlmock.AnyArg(), "john", 40).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content"}))
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:  "hashtags",
+			query: "?q=%23John&type=hashtags",
+			mockFunc: func() {
+				expectTags("john%", "john", 40)
+			},
+			wantCode:     http.StatusOK,
+			wantHashtags: 1,
+		},
+		{
+			name:     "without q",
+			query:    "?type=accounts",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unknown type",
+			query:    "?q=john&type=media",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodGet, "/v2/search"+tt.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.isAuth {
+				testutil.SetAuth(r)
+			}
+			if tt.mockFunc != nil {
+				tt.mockFunc()
+			}
+			auth.OptionalMiddleware(h.app)(http.HandlerFunc(h.Search)).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode == http.StatusOK {
+				var resp Response
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+				assert.Len(t, resp.Accounts, tt.wantAccounts)
+				assert.Len(t, resp.Statuses, 0)
+				assert.Len(t, resp.Hashtags, tt.wantHashtags)
+				for _, tag := range resp.Hashtags {
+					assert.Equal(t, "/v1/timelines/tag/"+tag.Name, tag.URL)
+				}
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func newMockHandler(db *sql.DB) *handler {
+	d := dao.NewWithDB(sqlx.NewDb(db, "sqlmock"))
+	return &handler{
+		app: &app.App{
+			Dao:    d,
+			Search: d.Search(),
+		},
+	}
+}
@@ -0,0 +1,57 @@
+package statuses
+
+import (
+	"encoding/json"
+	"net/http"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/presenter"
+	"yatter-backend-go/app/handler/visibility"
+)
+
+type Context struct {
+	// Parents in the thread, oldest first
+	Ancestors []*object.Status `json:"ancestors"`
+
+	// Children in the thread, oldest first
+	Descendants []*object.Status `json:"descendants"`
+}
+
+// Handle request for `GET /v1/statuses/{id}/context`
+func (h *handler) Context(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	viewer := auth.AccountOf(r)
+
+	status, ok := h.retrieveVisible(w, r)
+	if !ok {
+		return
+	}
+
+	ancestors, descendants, err := h.app.Dao.Status().Context(ctx, status)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	var res Context
+	if res.Ancestors, err = visibility.Filter(ctx, h.app.Dao, viewer, ancestors); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if res.Descendants, err = visibility.Filter(ctx, h.app.Dao, viewer, descendants); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if err := presenter.Statuses(ctx, h.app.Dao, viewer, append(append([]*object.Status{}, res.Ancestors...), res.Descendants...)); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
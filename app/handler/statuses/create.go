@@ -1,16 +1,38 @@
 package statuses
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"yatter-backend-go/app/domain/object"
 	"yatter-backend-go/app/handler/auth"
 	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/visibility"
+
+	"github.com/pkg/errors"
 )
 
 type AddRequest struct {
-	Status string
+	Status      string
+	MediaIDs    []uint64 `json:"media_ids"`
+	InReplyToID *uint64  `json:"in_reply_to_id"`
+	Visibility  string
+	SpoilerText string `json:"spoiler_text"`
+	Sensitive   bool
+	Poll        *PollRequest
+	// Publish the status at this time instead of now
+	ScheduledAt *time.Time `json:"scheduled_at"`
+}
+
+type PollRequest struct {
+	Options []string
+	// Duration the poll stays open in seconds
+	ExpiresIn  int64 `json:"expires_in"`
+	Multiple   bool
+	HideTotals bool `json:"hide_totals"`
 }
 
 // Handle request for `POST /v1/statuses`
@@ -28,10 +50,103 @@ func (h *handler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.Status == "" && len(req.MediaIDs) == 0 {
+		httperror.BadRequest(w, errors.New("status or media_ids is required"))
+		return
+	}
+	if req.Visibility == "" {
+		req.Visibility = object.VisibilityPublic
+	}
+	if !object.IsValidVisibility(req.Visibility) {
+		httperror.BadRequest(w, errors.Errorf("unknown visibility %q", req.Visibility))
+		return
+	}
+	if len(req.MediaIDs) > object.MaxStatusAttachments {
+		httperror.BadRequest(w, errors.Errorf("at most %d media can be attached", object.MaxStatusAttachments))
+		return
+	}
+
+	var poll *object.Poll
+	if req.Poll != nil {
+		if len(req.MediaIDs) > 0 {
+			httperror.BadRequest(w, errors.New("poll cannot be attached with media"))
+			return
+		}
+		var err error
+		poll, err = object.NewPoll(req.Poll.Options, time.Duration(req.Poll.ExpiresIn)*time.Second, req.Poll.Multiple, req.Poll.HideTotals, time.Now())
+		if err != nil {
+			httperror.BadRequest(w, err)
+			return
+		}
+	}
+
+	var inReplyTo *object.Status
+	if req.InReplyToID != nil {
+		var err error
+		inReplyTo, err = h.app.Dao.Status().Retrieve(ctx, *req.InReplyToID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				httperror.BadRequest(w, errors.Errorf("status %d to reply to was not found", *req.InReplyToID))
+				return
+			}
+			httperror.InternalServerError(w, err)
+			return
+		}
+		visible, err := visibility.Filter(ctx, h.app.Dao, account, []*object.Status{inReplyTo})
+		if err != nil {
+			httperror.InternalServerError(w, err)
+			return
+		}
+		if len(visible) == 0 {
+			httperror.BadRequest(w, errors.Errorf("status %d to reply to was not found", *req.InReplyToID))
+			return
+		}
+	}
+
+	attachments, err := h.app.Dao.Attachment().RetrieveByIDs(ctx, req.MediaIDs)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if len(attachments) != len(req.MediaIDs) {
+		httperror.BadRequest(w, errors.New("media not found"))
+		return
+	}
+	for _, attachment := range attachments {
+		if attachment.AccountID != account.ID || attachment.StatusID != nil || attachment.ScheduledStatusID != nil {
+			httperror.BadRequest(w, errors.Errorf("media %d cannot be attached", attachment.ID))
+			return
+		}
+	}
+
 	status := new(object.Status)
 	status.AccountId = account.ID
-	status.Content = req.Status
+	status.Visibility = req.Visibility
+	status.Sensitive = req.Sensitive
+	if err := status.SetSpoilerText(req.SpoilerText); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	status.Account = account
+	if inReplyTo != nil {
+		status.InReplyToID = &inReplyTo.ID
+		status.InReplyToAccountID = &inReplyTo.AccountId
+	}
+	status.MediaAttachments = attachments
+	status.Poll = poll
+	if status.MediaAttachments == nil {
+		status.MediaAttachments = []*object.Attachment{}
+	}
 
+	if req.ScheduledAt != nil {
+		h.schedule(w, r, &req, status)
+		return
+	}
+
+	if err := h.setText(ctx, status, req.Status); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
 	repo := h.app.Dao.Status()
 	if err := repo.Create(ctx, status); err != nil {
 		httperror.InternalServerError(w, err)
@@ -43,3 +158,13 @@ func (h *handler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// Render text into the content of the status, linking the mentioned accounts that exist
+func (h *handler) setText(ctx context.Context, status *object.Status, text string) error {
+	mentioned, err := h.app.Dao.Account().RetrieveByUsernames(ctx, object.ParseMentions(text))
+	if err != nil {
+		return err
+	}
+	status.SetText(text, mentioned)
+	return nil
+}
@@ -1,13 +1,22 @@
 package statuses
 
 import (
+	"database/sql"
+	"encoding/json"
 	"net/http"
+	"yatter-backend-go/app/handler/auth"
 	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/presenter"
 	"yatter-backend-go/app/handler/request"
 )
 
 // Handler request for `DELETE /v1/statuses/id`
 func (h *handler) Delete(w http.ResponseWriter, r *http.Request) {
+	account := auth.AccountOf(r)
+	if account == nil {
+		httperror.Error(w, http.StatusUnauthorized)
+		return
+	}
 	ctx := r.Context()
 
 	id, err := request.IDOf(r)
@@ -16,14 +25,35 @@ func (h *handler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if _, err := h.app.Dao.Status().Retrieve(ctx, id); err != nil {
-		httperror.NotFound(w, err)
+	status, err := h.app.Dao.Status().Retrieve(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			httperror.NotFound(w, id)
+			return
+		}
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if status.AccountId != account.ID {
+		httperror.Error(w, http.StatusForbidden)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
+	// 削除後は読み込めなくなるため、先にレスポンスを組み立てておく
+	if err := presenter.Status(ctx, h.app.Dao, account, status); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	status.Text = &status.Source
+
 	if err := h.app.Dao.Status().Delete(ctx, id); err != nil {
 		httperror.InternalServerError(w, err)
 		return
 	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
 }
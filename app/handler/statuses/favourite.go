@@ -0,0 +1,66 @@
+package statuses
+
+import (
+	"encoding/json"
+	"net/http"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/presenter"
+)
+
+// Handle request for `POST /v1/statuses/{id}/favourite`
+func (h *handler) Favourite(w http.ResponseWriter, r *http.Request) {
+	h.toggleFavourite(w, r, true)
+}
+
+// Handle request for `POST /v1/statuses/{id}/unfavourite`
+func (h *handler) Unfavourite(w http.ResponseWriter, r *http.Request) {
+	h.toggleFavourite(w, r, false)
+}
+
+func (h *handler) toggleFavourite(w http.ResponseWriter, r *http.Request, favourite bool) {
+	account := auth.AccountOf(r)
+	if account == nil {
+		httperror.Error(w, http.StatusUnauthorized)
+		return
+	}
+	ctx := r.Context()
+
+	target, ok := h.retrieveVisible(w, r)
+	if !ok {
+		return
+	}
+	// ブーストをお気に入りにすると元の投稿をお気に入りにする
+	if target, ok = h.resolveReblog(w, r, target); !ok {
+		return
+	}
+	id := target.ID
+
+	var err error
+	if favourite {
+		err = h.app.Dao.Favourite().Create(ctx, account.ID, id)
+	} else {
+		err = h.app.Dao.Favourite().Delete(ctx, account.ID, id)
+	}
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	// favourites_count を反映するため取り直す
+	status, err := h.app.Dao.Status().Retrieve(ctx, id)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if err := presenter.Status(ctx, h.app.Dao, account, status); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
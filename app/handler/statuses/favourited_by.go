@@ -0,0 +1,40 @@
+package statuses
+
+import (
+	"encoding/json"
+	"net/http"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Handle request for `GET /v1/statuses/{id}/favourited_by`
+func (h *handler) FavouritedBy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit, err := request.ParseLimitQuery(r.URL.Query().Get("limit"))
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	status, ok := h.retrieveVisible(w, r)
+	if !ok {
+		return
+	}
+
+	accounts, err := h.app.Dao.Favourite().RetrieveFavouritedBy(ctx, status.ID, limit)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if accounts == nil {
+		accounts = []object.Account{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(accounts); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
@@ -1,38 +1,29 @@
 package statuses
 
 import (
-	"database/sql"
 	"encoding/json"
 	"net/http"
+	"yatter-backend-go/app/handler/auth"
 	"yatter-backend-go/app/handler/httperror"
-	"yatter-backend-go/app/handler/request"
+	"yatter-backend-go/app/handler/presenter"
 )
 
 // Handler request for `GET /v1/statuses/id`
 func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	id, err := request.IDOf(r)
-	if err != nil {
-		httperror.BadRequest(w, err)
+	objStatus, ok := h.retrieveVisible(w, r)
+	if !ok {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	if objAccount, err := h.app.Dao.Status().Retrieve(ctx, id); err != nil {
-		if err == sql.ErrNoRows {
-			httperror.NotFound(w, id)
-			return
-		}
+	if err := presenter.Status(ctx, h.app.Dao, auth.AccountOf(r), objStatus); err != nil {
 		httperror.InternalServerError(w, err)
 		return
-	} else if objAccount != nil {
-		if err := json.NewEncoder(w).Encode(objAccount); err != nil {
-			httperror.InternalServerError(w, err)
-			return
-		}
-	} else {
-		httperror.NotFound(w, id)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(objStatus); err != nil {
+		httperror.InternalServerError(w, err)
 		return
 	}
 }
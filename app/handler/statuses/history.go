@@ -0,0 +1,46 @@
+package statuses
+
+import (
+	"encoding/json"
+	"net/http"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Handle request for `GET /v1/statuses/{id}/history`
+func (h *handler) History(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	status, ok := h.retrieveVisible(w, r)
+	if !ok {
+		return
+	}
+
+	edits, err := h.app.Dao.Status().RetrieveEdits(ctx, status.ID)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	// 最後に現在の内容を加える
+	current := &object.StatusEdit{StatusID: status.ID, Content: status.Content, CreateAt: status.CreateAt}
+	if status.EditedAt != nil {
+		current.CreateAt = *status.EditedAt
+	}
+	edits = append(edits, current)
+
+	account, err := h.app.Dao.Account().RetrieveByID(ctx, status.AccountId)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	for _, edit := range edits {
+		edit.Account = account
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(edits); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
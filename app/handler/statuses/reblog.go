@@ -0,0 +1,131 @@
+package statuses
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/presenter"
+)
+
+// Handle request for `POST /v1/statuses/{id}/reblog`
+func (h *handler) Reblog(w http.ResponseWriter, r *http.Request) {
+	account := auth.AccountOf(r)
+	if account == nil {
+		httperror.Error(w, http.StatusUnauthorized)
+		return
+	}
+	ctx := r.Context()
+
+	target, ok := h.reblogTarget(w, r)
+	if !ok {
+		return
+	}
+
+	// 既にブーストしている場合はそれを返す
+	reblog, err := h.app.Dao.Status().RetrieveReblog(ctx, account.ID, target.ID)
+	if err == sql.ErrNoRows {
+		reblog = &object.Status{
+			AccountId:  account.ID,
+			ReblogOfID: &target.ID,
+			HasMedia:   target.HasMedia,
+		}
+		err = h.app.Dao.Status().Create(ctx, reblog)
+		// 同時のリクエストに先を越された場合も、そのブーストを返す
+		if err == repository.ErrAlreadyReblogged {
+			reblog, err = h.app.Dao.Status().RetrieveReblog(ctx, account.ID, target.ID)
+		}
+	}
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	if err := presenter.Status(ctx, h.app.Dao, account, reblog); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(reblog); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
+
+// Handle request for `POST /v1/statuses/{id}/unreblog`
+func (h *handler) Unreblog(w http.ResponseWriter, r *http.Request) {
+	account := auth.AccountOf(r)
+	if account == nil {
+		httperror.Error(w, http.StatusUnauthorized)
+		return
+	}
+	ctx := r.Context()
+
+	target, ok := h.reblogTarget(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.app.Dao.Status().DeleteReblog(ctx, account.ID, target.ID); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	// reblogs_count を反映するため取り直す
+	status, err := h.app.Dao.Status().Retrieve(ctx, target.ID)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if err := presenter.Status(ctx, h.app.Dao, account, status); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
+
+// Retrieve the status to boost; a boost of a boost is resolved to the original status
+func (h *handler) reblogTarget(w http.ResponseWriter, r *http.Request) (*object.Status, bool) {
+	status, ok := h.retrieveVisible(w, r)
+	if !ok {
+		return nil, false
+	}
+	if status, ok = h.resolveReblog(w, r, status); !ok {
+		return nil, false
+	}
+
+	// フォロワー限定やダイレクトの投稿はブーストできない
+	if status.Visibility != object.VisibilityPublic && status.Visibility != object.VisibilityUnlisted {
+		httperror.Error(w, http.StatusForbidden)
+		return nil, false
+	}
+	return status, true
+}
+
+// Retrieve the original status of a boost, or return the status itself if it is not a boost
+func (h *handler) resolveReblog(w http.ResponseWriter, r *http.Request, status *object.Status) (*object.Status, bool) {
+	if status.ReblogOfID == nil {
+		return status, true
+	}
+
+	id := *status.ReblogOfID
+	original, err := h.app.Dao.Status().Retrieve(r.Context(), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			httperror.NotFound(w, id)
+			return nil, false
+		}
+		httperror.InternalServerError(w, err)
+		return nil, false
+	}
+	return original, true
+}
@@ -3,7 +3,9 @@ package statuses
 import (
 	"net/http"
 	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/domain/object"
 	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/idempotency"
 
 	"github.com/go-chi/chi"
 )
@@ -17,9 +19,17 @@ func NewRouter(app *app.App) http.Handler {
 	r := chi.NewRouter()
 
 	h := &handler{app: app}
-	r.With(auth.Middleware(app)).Post("/", h.Create)
-	r.Get("/{id}", h.Get)
-	r.Delete("/{id}", h.Delete)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeWriteStatuses), idempotency.Middleware(app)).Post("/", h.Create)
+	r.With(auth.OptionalMiddleware(app)).Get("/{id}", h.Get)
+	r.With(auth.OptionalMiddleware(app)).Get("/{id}/context", h.Context)
+	r.With(auth.OptionalMiddleware(app)).Get("/{id}/history", h.History)
+	r.With(auth.OptionalMiddleware(app)).Get("/{id}/favourited_by", h.FavouritedBy)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeWriteFavourites)).Post("/{id}/favourite", h.Favourite)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeWriteFavourites)).Post("/{id}/unfavourite", h.Unfavourite)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeWriteStatuses)).Post("/{id}/reblog", h.Reblog)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeWriteStatuses)).Post("/{id}/unreblog", h.Unreblog)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeWriteStatuses)).Put("/{id}", h.Update)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeWriteStatuses)).Delete("/{id}", h.Delete)
 
 	return r
 }
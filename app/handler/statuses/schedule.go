@@ -0,0 +1,50 @@
+package statuses
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Save the status validated by Create to be published at `scheduled_at` instead of posting it now
+func (h *handler) schedule(w http.ResponseWriter, r *http.Request, req *AddRequest, status *object.Status) {
+	ctx := r.Context()
+
+	scheduled := &object.ScheduledStatus{
+		AccountID: status.AccountId,
+		Params: object.ScheduledStatusParams{
+			Text:        req.Status,
+			MediaIDs:    req.MediaIDs,
+			InReplyToID: status.InReplyToID,
+			Visibility:  status.Visibility,
+			SpoilerText: status.SpoilerText,
+			Sensitive:   status.Sensitive,
+		},
+		MediaAttachments: status.MediaAttachments,
+	}
+	if req.Poll != nil {
+		// 投票の期限は公開時から数える
+		scheduled.Params.Poll = &object.PollParams{
+			Options:    req.Poll.Options,
+			ExpiresIn:  req.Poll.ExpiresIn,
+			Multiple:   req.Poll.Multiple,
+			HideTotals: req.Poll.HideTotals,
+		}
+	}
+	if err := scheduled.SetScheduledAt(*req.ScheduledAt, time.Now()); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	if err := h.app.Dao.ScheduledStatus().Create(ctx, scheduled); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(scheduled); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
@@ -4,17 +4,22 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 	"yatter-backend-go/app/app"
 	"yatter-backend-go/app/dao"
 	"yatter-backend-go/app/domain/object"
 	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/testutil"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-chi/chi"
+	"github.com/go-sql-driver/mysql"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
@@ -26,11 +31,14 @@ func TestCreateHandler(t *testing.T) {
 	defer db.Close()
 
 	tests := []struct {
-		name     string
-		body     *AddRequest
-		username string
-		mockFunc func()
-		wantCode int
+		name         string
+		body         *AddRequest
+		username     string
+		mockFunc     func()
+		wantCode     int
+		wantContent  string
+		wantMentions int
+		wantTags     int
 	}{
 		{
 			name: "successfully create status",
@@ -39,15 +47,286 @@ func TestCreateHandler(t *testing.T) {
 			},
 			username: "testuser",
 			mockFunc: func() {
-				mock.ExpectQuery("select \\* from account where username = \\?").
-					WithArgs("testuser").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
-				mock.ExpectExec("insert into status \\(account_id, content\\) values \\(\\?, \\?\\)").
-					WithArgs(1, "test post").
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectBegin()
+				mock.ExpectExec(insertStatusQuery).
+					WithArgs(1, "<p>test post</p>", "test post", "", false, "public", false, nil, nil, nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("update status set thread_path = \\? where id = \\?").
+					WithArgs("1/", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "successfully create status with mention and tag",
+			body: &AddRequest{
+				Status: "hi @alice #Go",
+			},
+			username: "testuser",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from account where username in \\(\\?\\)").
+					WithArgs("alice").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "alice"))
+				mock.ExpectBegin()
+				mock.ExpectExec(insertStatusQuery).
+					WithArgs(1, sqlmock.AnyArg(), "hi @alice #Go", "", false, "public", false, nil, nil, nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("update status set thread_path = \\? where id = \\?").
+					WithArgs("1/", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("insert into mention \\(status_id, account_id\\) values \\(\\?, \\?\\)").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("insert into notification \\(account_id, from_account_id, type, status_id, status_key\\) values").
+					WithArgs(2, 1, "mention", 1, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("insert into tag \\(name\\) values \\(\\?\\) on duplicate key update id = last_insert_id\\(id\\)").
+					WithArgs("go").
+					WillReturnResult(sqlmock.NewResult(4, 1))
+				mock.ExpectExec("insert into status_tag \\(status_id, tag_id\\) values \\(\\?, \\?\\)").
+					WithArgs(1, 4).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantCode:     http.StatusOK,
+			wantContent:  `<p>hi <span class="h-card"><a href="/v1/accounts/alice" class="u-url mention">@<span>alice</span></a></span> <a href="/v1/timelines/tag/go" class="mention hashtag" rel="tag">#<span>Go</span></a></p>`,
+			wantMentions: 1,
+			wantTags:     1,
+		},
+		{
+			name: "successfully create status with media",
+			body: &AddRequest{
+				Status:   "test post",
+				MediaIDs: []uint64{10},
+			},
+			username: "testuser",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from attachment where id in \\(\\?\\) order by id").
+					WithArgs(10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "type", "url"}).AddRow(10, 1, "image", "/media/a.png"))
+				mock.ExpectBegin()
+				mock.ExpectExec(insertStatusQuery).
+					WithArgs(1, "<p>test post</p>", "test post", "", false, "public", true, nil, nil, nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("update status set thread_path = \\? where id = \\?").
+					WithArgs("1/", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("update attachment set status_id = \\? where id in \\(\\?\\) and account_id = \\? and status_id is null and scheduled_status_id is null").
+					WithArgs(1, 10, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "successfully create status with content warning",
+			body: &AddRequest{
+				Status:      "test post",
+				SpoilerText: "ネタバレ",
+			},
+			username: "testuser",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectBegin()
+				mock.ExpectExec(insertStatusQuery).
+					WithArgs(1, "<p>test post</p>", "test post", "ネタバレ", true, "public", false, nil, nil, nil).
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("update status set thread_path = \\? where id = \\?").
+					WithArgs("1/", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "successfully create status with poll",
+			body: &AddRequest{
+				Status: "test post",
+				Poll:   &PollRequest{Options: []string{"yes", "no"}, ExpiresIn: 3600},
+			},
+			username: "testuser",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectBegin()
+				mock.ExpectExec(insertStatusQuery).
+					WithArgs(1, "<p>test post</p>", "test post", "", false, "public", false, nil, nil, nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("update status set thread_path = \\? where id = \\?").
+					WithArgs("1/", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("insert into poll \\(account_id, status_id, expires_at, multiple, hide_totals\\) values \\(\\?, \\?, \\?, \\?, \\?\\)").
+					WithArgs(1, 1, sqlmock.AnyArg(), false, false).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+				mock.ExpectExec("insert into poll_option \\(poll_id, position, title\\) values \\(\\?, \\?, \\?\\)").
+					WithArgs(3, 0, "yes").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("insert into poll_option \\(poll_id, position, title\\) values \\(\\?, \\?, \\?\\)").
+					WithArgs(3, 1, "no").
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectExec("update status set poll_id = \\? where id = \\?").
+					WithArgs(3, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "poll with media",
+			body: &AddRequest{
+				Status:   "test post",
+				MediaIDs: []uint64{10},
+				Poll:     &PollRequest{Options: []string{"yes", "no"}, ExpiresIn: 3600},
+			},
+			username: "testuser",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "poll with too short duration",
+			body: &AddRequest{
+				Status: "test post",
+				Poll:   &PollRequest{Options: []string{"yes", "no"}, ExpiresIn: 60},
+			},
+			username: "testuser",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "successfully schedule status",
+			body: &AddRequest{
+				Status:      "test post",
+				ScheduledAt: newTime(time.Now().Add(time.Hour)),
+			},
+			username: "testuser",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectBegin()
+				mock.ExpectExec("insert into scheduled_status \\(account_id, scheduled_at, params\\) values \\(\\?, \\?, \\?\\)").
+					WithArgs(1, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(7, 1))
+				mock.ExpectCommit()
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "schedule too soon",
+			body: &AddRequest{
+				Status:      "test post",
+				ScheduledAt: newTime(time.Now().Add(time.Minute)),
+			},
+			username: "testuser",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "successfully reply to status",
+			body: &AddRequest{
+				Status:      "test post",
+				InReplyToID: newUint64(5),
+			},
+			username: "testuser",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(5).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility", "thread_path"}).AddRow(5, 2, "parent", "public", "5/"))
+				expectNoBlocks(mock, 1, 2)
+				mock.ExpectBegin()
+				mock.ExpectExec(insertStatusQuery).
+					WithArgs(1, "<p>test post</p>", "test post", "", false, "public", false, 5, 2, nil).
+					WillReturnResult(sqlmock.NewResult(6, 1))
+				mock.ExpectQuery("select thread_path from status where id = \\?").
+					WithArgs(5).
+					WillReturnRows(sqlmock.NewRows([]string{"thread_path"}).AddRow("5/"))
+				mock.ExpectExec("update status set thread_path = \\? where id = \\?").
+					WithArgs("5/6/", 6).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
 			wantCode: http.StatusOK,
 		},
+		{
+			name: "reply to missing status",
+			body: &AddRequest{
+				Status:      "test post",
+				InReplyToID: newUint64(42),
+			},
+			username: "testuser",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(42).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "media of another account",
+			body: &AddRequest{
+				Status:   "test post",
+				MediaIDs: []uint64{10},
+			},
+			username: "testuser",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from attachment where id in \\(\\?\\) order by id").
+					WithArgs(10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "type", "url"}).AddRow(10, 2, "image", "/media/a.png"))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "media reserved for scheduled status",
+			body: &AddRequest{
+				Status:   "test post",
+				MediaIDs: []uint64{10},
+			},
+			username: "testuser",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from attachment where id in \\(\\?\\) order by id").
+					WithArgs(10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "scheduled_status_id", "type", "url"}).AddRow(10, 1, 7, "image", "/media/a.png"))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unknown visibility",
+			body:     &AddRequest{Status: "test post", Visibility: "secret"},
+			username: "testuser",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "too long spoiler_text",
+			body:     &AddRequest{Status: "test post", SpoilerText: strings.Repeat("a", object.MaxSpoilerTextLength+1)},
+			username: "testuser",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "empty status",
+			body:     &AddRequest{},
+			username: "testuser",
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+			},
+			wantCode: http.StatusBadRequest,
+		},
 		{
 			name:     "unauthorized",
 			body:     &AddRequest{Status: "test post"},
@@ -57,9 +336,7 @@ func TestCreateHandler(t *testing.T) {
 			name:     "bad request on malformed JSON",
 			username: "testuser",
 			mockFunc: func() {
-				mock.ExpectQuery("select \\* from account where username = \\?").
-					WithArgs("testuser").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
+				testutil.ExpectAuth(mock, 1, "testuser")
 			},
 			wantCode: http.StatusBadRequest,
 		},
@@ -82,7 +359,7 @@ func TestCreateHandler(t *testing.T) {
 				t.Fatal(err)
 			}
 			if tt.username != "" {
-				r.Header.Set("Authentication", "username "+tt.username)
+				testutil.SetAuth(r)
 			}
 			if tt.mockFunc != nil {
 				tt.mockFunc()
@@ -94,12 +371,33 @@ func TestCreateHandler(t *testing.T) {
 
 			assert.Equal(t, tt.wantCode, w.Code)
 
-			if tt.wantCode == http.StatusOK {
+			if tt.wantCode == http.StatusOK && tt.body.ScheduledAt != nil {
+				var resp object.ScheduledStatus
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, uint64(7), resp.ID)
+				assert.Equal(t, tt.body.Status, resp.Params.Text)
+				assert.Equal(t, tt.body.ScheduledAt.Unix(), resp.ScheduledAt.Unix())
+			} else if tt.wantCode == http.StatusOK {
 				var resp object.Status
 				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
 					t.Fatal(err)
 				}
-				assert.Equal(t, tt.body.Status, resp.Content)
+				wantContent := tt.wantContent
+				if wantContent == "" {
+					wantContent = "<p>" + tt.body.Status + "</p>"
+				}
+				assert.Equal(t, wantContent, resp.Content)
+				assert.Len(t, resp.Mentions, tt.wantMentions)
+				assert.Len(t, resp.Tags, tt.wantTags)
+				assert.Equal(t, tt.body.InReplyToID, resp.InReplyToID)
+				assert.Equal(t, tt.body.SpoilerText, resp.SpoilerText)
+				assert.Equal(t, tt.body.Sensitive || tt.body.SpoilerText != "", resp.Sensitive)
+				if tt.body.Poll != nil && assert.NotNil(t, resp.Poll) {
+					assert.Equal(t, len(tt.body.Poll.Options), len(resp.Poll.Options))
+					assert.False(t, resp.Poll.Expired)
+				}
 			}
 		})
 	}
@@ -111,10 +409,12 @@ func TestFindHandler(t *testing.T) {
 	defer db.Close()
 
 	tests := []struct {
-		name     string
-		id       string
-		mockFunc func()
-		wantCode int
+		name         string
+		id           string
+		isAuth       bool
+		mockFunc     func()
+		wantCode     int
+		wantUsername string
 	}{
 		{
 			name: "successfully find status",
@@ -122,10 +422,23 @@ func TestFindHandler(t *testing.T) {
 			mockFunc: func() {
 				mock.ExpectQuery("select \\* from status where id = \\?").
 					WithArgs(1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content"}).
-						AddRow(1, 1, "test post"))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility"}).
+						AddRow(1, 1, "test post", "public"))
+				mock.ExpectQuery("select \\* from account where id in \\(\\?\\)").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
+				mock.ExpectQuery("select \\* from attachment where status_id in \\(\\?\\) order by id").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "status_id", "type", "url"}).AddRow(10, 1, 1, "image", "/media/a.png"))
+				mock.ExpectQuery("select mention\\.status_id, mention\\.account_id, account\\.username from mention join account on account\\.id = mention\\.account_id where mention\\.status_id in \\(\\?\\) order by mention\\.id").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"status_id", "account_id", "username"}))
+				mock.ExpectQuery("select tag\\.\\*, status_tag\\.status_id from tag join status_tag on status_tag\\.tag_id = tag\\.id where status_tag\\.status_id in \\(\\?\\) order by status_tag\\.status_id, tag\\.name").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status_id"}))
 			},
-			wantCode: http.StatusOK,
+			wantCode:     http.StatusOK,
+			wantUsername: "testuser",
 		},
 		{
 			name: "not found",
@@ -137,14 +450,128 @@ func TestFindHandler(t *testing.T) {
 			},
 			wantCode: http.StatusNotFound,
 		},
+		{
+			name: "private status to anonymous",
+			id:   "1",
+			mockFunc: func() {
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility"}).
+						AddRow(1, 2, "test post", "private"))
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:   "token without read:statuses",
+			id:     "1",
+			isAuth: true,
+			mockFunc: func() {
+				// 閲覧者ではなく匿名として扱われるため、自分の非公開の投稿も見られない
+				testutil.ExpectAuthWithScopes(mock, 1, "testuser", "write:media follow")
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility"}).
+						AddRow(1, 1, "test post", "private"))
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:   "status of blocked account",
+			id:     "1",
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility"}).
+						AddRow(1, 2, "test post", "public"))
+				mock.ExpectQuery(blockedQuery).
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"target_account_id"}).AddRow(2))
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:   "reblog of blocked account's status",
+			id:     "3",
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility", "reblog_of_id"}).
+						AddRow(3, 4, "", "public", 1))
+				mock.ExpectQuery("select \\* from status where id in \\(\\?\\)").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility"}).
+						AddRow(1, 2, "test post", "public"))
+				mock.ExpectQuery(blockedQuery).
+					WithArgs(1, 4, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"target_account_id"}).AddRow(2))
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:   "private status to non follower",
+			id:     "1",
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility"}).
+						AddRow(1, 2, "test post", "private"))
+				expectNoBlocks(mock, 1, 2)
+				mock.ExpectQuery("select count\\(\\*\\) from relationship where following_id = \\? and follower_id = \\?").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:   "private status to follower",
+			id:     "1",
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility"}).
+						AddRow(1, 2, "test post", "private"))
+				expectNoBlocks(mock, 1, 2)
+				mock.ExpectQuery("select count\\(\\*\\) from relationship where following_id = \\? and follower_id = \\?").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery("select \\* from account where id in \\(\\?\\)").
+					WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "otheruser"))
+				mock.ExpectQuery("select \\* from attachment where status_id in \\(\\?\\) order by id").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "status_id", "type", "url"}))
+				mock.ExpectQuery("select mention\\.status_id, mention\\.account_id, account\\.username from mention join account on account\\.id = mention\\.account_id where mention\\.status_id in \\(\\?\\) order by mention\\.id").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"status_id", "account_id", "username"}))
+				mock.ExpectQuery("select tag\\.\\*, status_tag\\.status_id from tag join status_tag on status_tag\\.tag_id = tag\\.id where status_tag\\.status_id in \\(\\?\\) order by status_tag\\.status_id, tag\\.name").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status_id"}))
+				mock.ExpectQuery("select status_id from favourite where account_id = \\? and status_id in \\(\\?\\)").
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"status_id"}))
+				mock.ExpectQuery("select reblog_of_id from status where account_id = \\? and reblog_of_id in \\(\\?\\)").
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"reblog_of_id"}))
+			},
+			wantCode:     http.StatusOK,
+			wantUsername: "otheruser",
+		},
 		{
 			name: "bad request on param",
 			id:   "invalid",
 			mockFunc: func() {
 				mock.ExpectQuery("select \\* from status where id = \\?").
 					WithArgs(1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content"}).
-						AddRow(1, 1, "test post"))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility"}).
+						AddRow(1, 1, "test post", "public"))
 			},
 			wantCode: http.StatusBadRequest,
 		},
@@ -158,10 +585,13 @@ func TestFindHandler(t *testing.T) {
 				t.Fatal(err)
 			}
 			r = setChiURLParam(r, "id", tt.id)
+			if tt.isAuth {
+				testutil.SetAuth(r)
+			}
 			if tt.mockFunc != nil {
 				tt.mockFunc()
 			}
-			h.Get(w, r)
+			auth.OptionalMiddleware(h.app)(http.HandlerFunc(h.Get)).ServeHTTP(w, r)
 
 			assert.Equal(t, tt.wantCode, w.Code)
 			if tt.wantCode == http.StatusOK {
@@ -169,63 +599,76 @@ func TestFindHandler(t *testing.T) {
 				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
 					t.Fatal(err)
 				}
-				assert.NotEmpty(t, resp)
+				assert.Equal(t, uint64(1), resp.ID)
+				if assert.NotNil(t, resp.Account) {
+					assert.Equal(t, tt.wantUsername, resp.Account.Username)
+				}
 			}
 		})
 	}
 }
 
-func TestDeleteHandler(t *testing.T) {
+func TestContextHandler(t *testing.T) {
 	db, mock := dao.NewMockDB()
 	h := newMockHandler(db)
 	defer db.Close()
 
 	tests := []struct {
-		name     string
-		id       string
-		mockFunc func()
-		wantCode int
+		name            string
+		id              string
+		mockFunc        func()
+		wantCode        int
+		wantAncestors   int
+		wantDescendants int
 	}{
 		{
-			name: "successfully delete status",
-			id:   "1",
+			name: "successfully get context",
+			id:   "2",
 			mockFunc: func() {
 				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility", "thread_path"}).AddRow(2, 1, "reply", "public", "1/2/"))
+				mock.ExpectQuery("select \\* from status where id in \\(\\?\\) order by id").
 					WithArgs(1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content"}).
-						AddRow(1, 1, "test post"))
-				mock.ExpectExec("delete from status where id = \\?").
-					WithArgs(1).
-					WillReturnResult(sqlmock.NewResult(-1, 1))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility", "thread_path"}).AddRow(1, 1, "root", "public", "1/"))
+				mock.ExpectQuery("select \\* from status where thread_path like \\? and id <> \\? order by id").
+					WithArgs("1/2/%", 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility", "thread_path"}).
+						AddRow(3, 2, "reply to reply", "public", "1/2/3/").
+						AddRow(4, 1, "another reply", "public", "1/2/4/"))
+				mock.ExpectQuery("select \\* from account where id in \\(\\?, \\?\\)").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser").AddRow(2, "otheruser"))
+				mock.ExpectQuery("select \\* from attachment where status_id in \\(\\?, \\?, \\?\\) order by id").
+					WithArgs(1, 3, 4).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "status_id", "type", "url"}))
+				mock.ExpectQuery("select mention\\.status_id, mention\\.account_id, account\\.username from mention join account on account\\.id = mention\\.account_id where mention\\.status_id in \\(\\?, \\?, \\?\\) order by mention\\.id").
+					WithArgs(1, 3, 4).
+					WillReturnRows(sqlmock.NewRows([]string{"status_id", "account_id", "username"}))
+				mock.ExpectQuery("select tag\\.\\*, status_tag\\.status_id from tag join status_tag on status_tag\\.tag_id = tag\\.id where status_tag\\.status_id in \\(\\?, \\?, \\?\\) order by status_tag\\.status_id, tag\\.name").
+					WithArgs(1, 3, 4).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status_id"}))
 			},
-			wantCode: http.StatusOK,
+			wantCode:        http.StatusOK,
+			wantAncestors:   1,
+			wantDescendants: 2,
 		},
 		{
 			name: "not found",
 			id:   "42",
 			mockFunc: func() {
-				mock.ExpectExec("delete from status where id = \\?").
+				mock.ExpectQuery("select \\* from status where id = \\?").
 					WithArgs(42).
 					WillReturnError(sql.ErrNoRows)
 			},
 			wantCode: http.StatusNotFound,
 		},
-		{
-			name: "bad request on param",
-			id:   "invalid",
-			mockFunc: func() {
-				mock.ExpectExec("delete from status where id = \\?").
-					WithArgs(1).
-					WillReturnResult(sqlmock.NewResult(1, 1))
-			},
-			wantCode: http.StatusBadRequest,
-		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
-			r, err := http.NewRequest(http.MethodDelete, "/v1/status/"+tt.id, nil)
+			r, err := http.NewRequest(http.MethodGet, "/v1/statuses/"+tt.id+"/context", nil)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -233,23 +676,864 @@ func TestDeleteHandler(t *testing.T) {
 			if tt.mockFunc != nil {
 				tt.mockFunc()
 			}
-			h.Delete(w, r)
+			h.Context(w, r)
 
 			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode == http.StatusOK {
+				var resp Context
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tt.wantAncestors, len(resp.Ancestors))
+				assert.Equal(t, tt.wantDescendants, len(resp.Descendants))
+				if assert.NotNil(t, resp.Descendants[0].Account) {
+					assert.Equal(t, "otheruser", resp.Descendants[0].Account.Username)
+				}
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
 
-func newMockHandler(db *sql.DB) *handler {
-	return &handler{
-		app: &app.App{
-			Dao: dao.NewWithDB(sqlx.NewDb(db, "sqlmock")),
-		},
-	}
-}
+func TestDeleteHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
 
-func setChiURLParam(r *http.Request, key, value string) *http.Request {
-	rctx := chi.NewRouteContext()
-	rctx.URLParams.Add(key, value)
-	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+	tests := []struct {
+		name     string
+		id       string
+		isAuth   bool
+		mockFunc func()
+		wantCode int
+	}{
+		{
+			name:   "successfully delete status",
+			id:     "1",
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "text", "visibility"}).
+						AddRow(1, 1, "<p>test post</p>", "test post", "public"))
+				mock.ExpectQuery("select \\* from account where id in \\(\\?\\)").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
+				mock.ExpectQuery("select \\* from attachment where status_id in \\(\\?\\) order by id").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "status_id", "type", "url"}).AddRow(10, 1, 1, "image", "/media/a.png"))
+				mock.ExpectQuery("select mention\\.status_id, mention\\.account_id, account\\.username from mention join account on account\\.id = mention\\.account_id where mention\\.status_id in \\(\\?\\) order by mention\\.id").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"status_id", "account_id", "username"}))
+				mock.ExpectQuery("select tag\\.\\*, status_tag\\.status_id from tag join status_tag on status_tag\\.tag_id = tag\\.id where status_tag\\.status_id in \\(\\?\\) order by status_tag\\.status_id, tag\\.name").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status_id"}))
+				mock.ExpectQuery("select status_id from favourite where account_id = \\? and status_id in \\(\\?\\)").
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"status_id"}))
+				mock.ExpectQuery("select reblog_of_id from status where account_id = \\? and reblog_of_id in \\(\\?\\)").
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"reblog_of_id"}))
+				mock.ExpectBegin()
+				mock.ExpectQuery("select reblog_of_id from status where id = \\? for update").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"reblog_of_id"}).AddRow(nil))
+				mock.ExpectExec("delete from favourite where status_id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("delete from status_edit where status_id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("delete from poll where status_id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("delete from status where reblog_of_id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("update status set in_reply_to_id = null where in_reply_to_id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("update attachment set status_id = null where status_id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("delete from status where id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "status of another account",
+			id:     "1",
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility"}).
+						AddRow(1, 2, "test post", "public"))
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:   "not found",
+			id:     "42",
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(42).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:   "bad request on param",
+			id:     "invalid",
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unauthorized",
+			id:       "1",
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodDelete, "/v1/status/"+tt.id, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r = setChiURLParam(r, "id", tt.id)
+			if tt.isAuth {
+				testutil.SetAuth(r)
+			}
+			if tt.mockFunc != nil {
+				tt.mockFunc()
+			}
+			auth.Middleware(h.app)(http.HandlerFunc(h.Delete)).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode == http.StatusOK {
+				var resp object.Status
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+				if assert.NotNil(t, resp.Text) {
+					assert.Equal(t, "test post", *resp.Text)
+				}
+				assert.Equal(t, 1, len(resp.MediaAttachments))
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdateHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	editedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		id       string
+		body     *UpdateRequest
+		isAuth   bool
+		mockFunc func()
+		wantCode int
+	}{
+		{
+			name:   "successfully edit status",
+			id:     "1",
+			body:   &UpdateRequest{Status: "fixed post"},
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility"}).AddRow(1, 1, "test post", "public"))
+				mock.ExpectBegin()
+				mock.ExpectQuery("select id from status where id = \\? for update").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectExec("insert into status_edit \\(status_id, content, create_at\\) select id, content, coalesce\\(edited_at, create_at\\) from status where id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("update status set content = \\?, text = \\?, edited_at = now\\(\\) where id = \\?").
+					WithArgs("<p>fixed post</p>", "fixed post", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("delete from mention where status_id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("delete from status_tag where status_id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "text", "visibility", "edited_at"}).AddRow(1, 1, "<p>fixed post</p>", "fixed post", "public", editedAt))
+				mock.ExpectQuery("select \\* from account where id in \\(\\?\\)").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
+				mock.ExpectQuery("select \\* from attachment where status_id in \\(\\?\\) order by id").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "status_id", "type", "url"}))
+				mock.ExpectQuery("select mention\\.status_id, mention\\.account_id, account\\.username from mention join account on account\\.id = mention\\.account_id where mention\\.status_id in \\(\\?\\) order by mention\\.id").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"status_id", "account_id", "username"}))
+				mock.ExpectQuery("select tag\\.\\*, status_tag\\.status_id from tag join status_tag on status_tag\\.tag_id = tag\\.id where status_tag\\.status_id in \\(\\?\\) order by status_tag\\.status_id, tag\\.name").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status_id"}))
+				mock.ExpectQuery("select status_id from favourite where account_id = \\? and status_id in \\(\\?\\)").
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"status_id"}))
+				mock.ExpectQuery("select reblog_of_id from status where account_id = \\? and reblog_of_id in \\(\\?\\)").
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"reblog_of_id"}))
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "status of another account",
+			id:     "1",
+			body:   &UpdateRequest{Status: "fixed post"},
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility"}).AddRow(1, 2, "test post", "public"))
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:   "empty status",
+			id:     "1",
+			body:   &UpdateRequest{},
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility"}).AddRow(1, 1, "test post", "public"))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unauthorized",
+			id:       "1",
+			body:     &UpdateRequest{Status: "fixed post"},
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bodyBytes, err := json.Marshal(tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodPut, "/v1/statuses/"+tt.id, bytes.NewReader(bodyBytes))
+			if err != nil {
+				t.Fatal(err)
+			}
+			r = setChiURLParam(r, "id", tt.id)
+			if tt.isAuth {
+				testutil.SetAuth(r)
+			}
+			if tt.mockFunc != nil {
+				tt.mockFunc()
+			}
+			auth.Middleware(h.app)(http.HandlerFunc(h.Update)).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode == http.StatusOK {
+				var resp object.Status
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, "<p>"+tt.body.Status+"</p>", resp.Content)
+				if assert.NotNil(t, resp.EditedAt) {
+					assert.True(t, editedAt.Equal(resp.EditedAt.Time))
+				}
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestHistoryHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	createAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	editedAt := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("select \\* from status where id = \\?").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility", "create_at", "edited_at"}).
+			AddRow(1, 1, "fixed post", "public", createAt, editedAt))
+	mock.ExpectQuery("select \\* from status_edit where status_id = \\? order by id").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status_id", "content", "create_at"}).AddRow(1, 1, "test post", createAt))
+	mock.ExpectQuery("select \\* from account where id = \\?").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest(http.MethodGet, "/v1/statuses/1/history", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r = setChiURLParam(r, "id", "1")
+	h.History(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp []object.StatusEdit
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 2, len(resp)) {
+		assert.Equal(t, "test post", resp[0].Content)
+		assert.Equal(t, "fixed post", resp[1].Content)
+		assert.True(t, editedAt.Equal(resp[1].CreateAt.Time))
+		assert.Equal(t, "testuser", resp[1].Account.Username)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFavouriteHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	statusRows := func(count int) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "account_id", "content", "visibility", "favourites_count"}).AddRow(1, 2, "test post", "public", count)
+	}
+	expectPresent := func(favourited bool) {
+		mock.ExpectQuery("select \\* from account where id in \\(\\?\\)").
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "otheruser"))
+		mock.ExpectQuery("select \\* from attachment where status_id in \\(\\?\\) order by id").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "status_id", "type", "url"}))
+		mock.ExpectQuery("select mention\\.status_id, mention\\.account_id, account\\.username from mention join account on account\\.id = mention\\.account_id where mention\\.status_id in \\(\\?\\) order by mention\\.id").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"status_id", "account_id", "username"}))
+		mock.ExpectQuery("select tag\\.\\*, status_tag\\.status_id from tag join status_tag on status_tag\\.tag_id = tag\\.id where status_tag\\.status_id in \\(\\?\\) order by status_tag\\.status_id, tag\\.name").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status_id"}))
+		rows := sqlmock.NewRows([]string{"status_id"})
+		if favourited {
+			rows.AddRow(1)
+		}
+		mock.ExpectQuery("select status_id from favourite where account_id = \\? and status_id in \\(\\?\\)").
+			WithArgs(1, 1).
+			WillReturnRows(rows)
+		mock.ExpectQuery("select reblog_of_id from status where account_id = \\? and reblog_of_id in \\(\\?\\)").
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"reblog_of_id"}))
+	}
+
+	tests := []struct {
+		name           string
+		id             string
+		handler        http.HandlerFunc
+		isAuth         bool
+		mockFunc       func()
+		wantCode       int
+		wantFavourited bool
+		wantCount      uint64
+	}{
+		{
+			name:    "successfully favourite",
+			id:      "1",
+			handler: h.Favourite,
+			isAuth:  true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").WithArgs(1).WillReturnRows(statusRows(0))
+				expectNoBlocks(mock, 1, 2)
+				mock.ExpectBegin()
+				mock.ExpectExec("insert ignore into favourite \\(account_id, status_id\\) values \\(\\?, \\?\\)").
+					WithArgs(1, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("update status set favourites_count = favourites_count \\+ 1 where id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("insert into notification \\(account_id, from_account_id, type, status_id, status_key\\) select account_id, \\?, \\?, id, id from status where id = \\? and account_id <> \\?").
+					WithArgs(1, "favourite", 1, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				mock.ExpectQuery("select \\* from status where id = \\?").WithArgs(1).WillReturnRows(statusRows(1))
+				expectPresent(true)
+			},
+			wantCode:       http.StatusOK,
+			wantFavourited: true,
+			wantCount:      1,
+		},
+		{
+			name:    "favourite a reblog",
+			id:      "3",
+			handler: h.Favourite,
+			isAuth:  true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "visibility", "reblog_of_id"}).AddRow(3, 1, "public", 1))
+				mock.ExpectQuery("select \\* from status where id in \\(\\?\\)").
+					WithArgs(1).
+					WillReturnRows(statusRows(0))
+				expectNoBlocks(mock, 1, 2)
+				mock.ExpectQuery("select \\* from status where id = \\?").WithArgs(1).WillReturnRows(statusRows(0))
+				mock.ExpectBegin()
+				mock.ExpectExec("insert ignore into favourite \\(account_id, status_id\\) values \\(\\?, \\?\\)").
+					WithArgs(1, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("update status set favourites_count = favourites_count \\+ 1 where id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("insert into notification \\(account_id, from_account_id, type, status_id, status_key\\) select account_id, \\?, \\?, id, id from status where id = \\? and account_id <> \\?").
+					WithArgs(1, "favourite", 1, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				mock.ExpectQuery("select \\* from status where id = \\?").WithArgs(1).WillReturnRows(statusRows(1))
+				expectPresent(true)
+			},
+			wantCode:       http.StatusOK,
+			wantFavourited: true,
+			wantCount:      1,
+		},
+		{
+			name:    "favourite twice",
+			id:      "1",
+			handler: h.Favourite,
+			isAuth:  true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").WithArgs(1).WillReturnRows(statusRows(1))
+				expectNoBlocks(mock, 1, 2)
+				mock.ExpectBegin()
+				mock.ExpectExec("insert ignore into favourite \\(account_id, status_id\\) values \\(\\?, \\?\\)").
+					WithArgs(1, 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+				mock.ExpectQuery("select \\* from status where id = \\?").WithArgs(1).WillReturnRows(statusRows(1))
+				expectPresent(true)
+			},
+			wantCode:       http.StatusOK,
+			wantFavourited: true,
+			wantCount:      1,
+		},
+		{
+			name:    "successfully unfavourite",
+			id:      "1",
+			handler: h.Unfavourite,
+			isAuth:  true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").WithArgs(1).WillReturnRows(statusRows(1))
+				expectNoBlocks(mock, 1, 2)
+				mock.ExpectBegin()
+				mock.ExpectExec("delete from favourite where account_id = \\? and status_id = \\?").
+					WithArgs(1, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("update status set favourites_count = favourites_count - 1 where id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectQuery("select \\* from status where id = \\?").WithArgs(1).WillReturnRows(statusRows(0))
+				expectPresent(false)
+			},
+			wantCode:       http.StatusOK,
+			wantFavourited: false,
+			wantCount:      0,
+		},
+		{
+			name:    "status not found",
+			id:      "42",
+			handler: h.Favourite,
+			isAuth:  true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").WithArgs(42).WillReturnError(sql.ErrNoRows)
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "unauthorized",
+			id:       "1",
+			handler:  h.Favourite,
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodPost, "/v1/statuses/"+tt.id+"/favourite", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r = setChiURLParam(r, "id", tt.id)
+			if tt.isAuth {
+				testutil.SetAuth(r)
+			}
+			if tt.mockFunc != nil {
+				tt.mockFunc()
+			}
+			auth.Middleware(h.app)(tt.handler).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode == http.StatusOK {
+				var resp object.Status
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, tt.wantFavourited, resp.Favourited)
+				assert.Equal(t, tt.wantCount, resp.FavouritesCount)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReblogHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	statusColumns := []string{"id", "account_id", "content", "visibility", "reblog_of_id", "reblogs_count"}
+	expectPresentReblog := func(reblogsCount int) {
+		mock.ExpectQuery("select \\* from status where id in \\(\\?\\)").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(statusColumns).AddRow(1, 2, "test post", "public", nil, reblogsCount))
+		mock.ExpectQuery("select \\* from account where id in \\(\\?, \\?\\)").
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser").AddRow(2, "otheruser"))
+		mock.ExpectQuery("select \\* from attachment where status_id in \\(\\?, \\?\\) order by id").
+			WithArgs(3, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "status_id", "type", "url"}))
+		mock.ExpectQuery("select mention\\.status_id, mention\\.account_id, account\\.username from mention join account on account\\.id = mention\\.account_id where mention\\.status_id in \\(\\?, \\?\\) order by mention\\.id").
+			WithArgs(3, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"status_id", "account_id", "username"}))
+		mock.ExpectQuery("select tag\\.\\*, status_tag\\.status_id from tag join status_tag on status_tag\\.tag_id = tag\\.id where status_tag\\.status_id in \\(\\?, \\?\\) order by status_tag\\.status_id, tag\\.name").
+			WithArgs(3, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status_id"}))
+		mock.ExpectQuery("select status_id from favourite where account_id = \\? and status_id in \\(\\?, \\?\\)").
+			WithArgs(1, 3, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"status_id"}))
+		mock.ExpectQuery("select reblog_of_id from status where account_id = \\? and reblog_of_id in \\(\\?, \\?\\)").
+			WithArgs(1, 3, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"reblog_of_id"}).AddRow(1))
+	}
+
+	tests := []struct {
+		name     string
+		id       string
+		handler  http.HandlerFunc
+		isAuth   bool
+		mockFunc func()
+		wantCode int
+		check    func(t *testing.T, resp object.Status)
+	}{
+		{
+			name:    "successfully reblog",
+			id:      "1",
+			handler: h.Reblog,
+			isAuth:  true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(statusColumns).AddRow(1, 2, "test post", "public", nil, 0))
+				expectNoBlocks(mock, 1, 2)
+				mock.ExpectQuery("select \\* from status where account_id = \\? and reblog_of_id = \\?").
+					WithArgs(1, 1).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectBegin()
+				mock.ExpectExec(insertStatusQuery).
+					WithArgs(1, "", "", "", false, "public", false, nil, nil, 1).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+				mock.ExpectExec("update status set thread_path = \\? where id = \\?").
+					WithArgs("3/", 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("update status set reblogs_count = reblogs_count \\+ 1 where id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("insert into notification \\(account_id, from_account_id, type, status_id, status_key\\) select account_id, \\?, \\?, id, id from status where id = \\? and account_id <> \\?").
+					WithArgs(1, "reblog", 1, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				expectPresentReblog(1)
+			},
+			wantCode: http.StatusOK,
+			check: func(t *testing.T, resp object.Status) {
+				assert.Equal(t, uint64(3), resp.ID)
+				if assert.NotNil(t, resp.Reblog) {
+					assert.Equal(t, uint64(1), resp.Reblog.ID)
+					assert.Equal(t, uint64(1), resp.Reblog.ReblogsCount)
+					assert.True(t, resp.Reblog.Reblogged)
+					assert.Equal(t, "otheruser", resp.Reblog.Account.Username)
+				}
+			},
+		},
+		{
+			name:    "reblogged concurrently",
+			id:      "1",
+			handler: h.Reblog,
+			isAuth:  true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(statusColumns).AddRow(1, 2, "test post", "public", nil, 1))
+				expectNoBlocks(mock, 1, 2)
+				mock.ExpectQuery("select \\* from status where account_id = \\? and reblog_of_id = \\?").
+					WithArgs(1, 1).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectBegin()
+				mock.ExpectExec(insertStatusQuery).
+					WithArgs(1, "", "", "", false, "public", false, nil, nil, 1).
+					WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+				mock.ExpectRollback()
+				mock.ExpectQuery("select \\* from status where account_id = \\? and reblog_of_id = \\?").
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows(statusColumns).AddRow(3, 1, "", "public", 1, 0))
+				expectPresentReblog(1)
+			},
+			wantCode: http.StatusOK,
+			check: func(t *testing.T, resp object.Status) {
+				assert.Equal(t, uint64(3), resp.ID)
+				if assert.NotNil(t, resp.Reblog) {
+					assert.Equal(t, uint64(1), resp.Reblog.ID)
+				}
+			},
+		},
+		{
+			name:    "reblog a reblog",
+			id:      "3",
+			handler: h.Reblog,
+			isAuth:  true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(3).
+					WillReturnRows(sqlmock.NewRows(statusColumns).AddRow(3, 1, "", "public", 1, 0))
+				mock.ExpectQuery("select \\* from status where id in \\(\\?\\)").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(statusColumns).AddRow(1, 2, "test post", "public", nil, 1))
+				expectNoBlocks(mock, 1, 2)
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(statusColumns).AddRow(1, 2, "test post", "public", nil, 1))
+				mock.ExpectQuery("select \\* from status where account_id = \\? and reblog_of_id = \\?").
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows(statusColumns).AddRow(3, 1, "", "public", 1, 0))
+				expectPresentReblog(1)
+			},
+			wantCode: http.StatusOK,
+			check: func(t *testing.T, resp object.Status) {
+				assert.Equal(t, uint64(3), resp.ID)
+				if assert.NotNil(t, resp.Reblog) {
+					assert.Equal(t, uint64(1), resp.Reblog.ID)
+				}
+			},
+		},
+		{
+			name:    "successfully unreblog",
+			id:      "1",
+			handler: h.Unreblog,
+			isAuth:  true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(statusColumns).AddRow(1, 2, "test post", "public", nil, 1))
+				expectNoBlocks(mock, 1, 2)
+				mock.ExpectBegin()
+				mock.ExpectExec("delete from status where account_id = \\? and reblog_of_id = \\?").
+					WithArgs(1, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("update status set reblogs_count = reblogs_count - 1 where id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(statusColumns).AddRow(1, 2, "test post", "public", nil, 0))
+				mock.ExpectQuery("select \\* from account where id in \\(\\?\\)").
+					WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "otheruser"))
+				mock.ExpectQuery("select \\* from attachment where status_id in \\(\\?\\) order by id").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "status_id", "type", "url"}))
+				mock.ExpectQuery("select mention\\.status_id, mention\\.account_id, account\\.username from mention join account on account\\.id = mention\\.account_id where mention\\.status_id in \\(\\?\\) order by mention\\.id").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"status_id", "account_id", "username"}))
+				mock.ExpectQuery("select tag\\.\\*, status_tag\\.status_id from tag join status_tag on status_tag\\.tag_id = tag\\.id where status_tag\\.status_id in \\(\\?\\) order by status_tag\\.status_id, tag\\.name").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status_id"}))
+				mock.ExpectQuery("select status_id from favourite where account_id = \\? and status_id in \\(\\?\\)").
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"status_id"}))
+				mock.ExpectQuery("select reblog_of_id from status where account_id = \\? and reblog_of_id in \\(\\?\\)").
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"reblog_of_id"}))
+			},
+			wantCode: http.StatusOK,
+			check: func(t *testing.T, resp object.Status) {
+				assert.Equal(t, uint64(1), resp.ID)
+				assert.Nil(t, resp.Reblog)
+				assert.False(t, resp.Reblogged)
+				assert.Equal(t, uint64(0), resp.ReblogsCount)
+			},
+		},
+		{
+			name:    "reblog private status",
+			id:      "5",
+			handler: h.Reblog,
+			isAuth:  true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").
+					WithArgs(5).
+					WillReturnRows(sqlmock.NewRows(statusColumns).AddRow(5, 1, "test post", "private", nil, 0))
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:    "status not found",
+			id:      "42",
+			handler: h.Reblog,
+			isAuth:  true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select \\* from status where id = \\?").WithArgs(42).WillReturnError(sql.ErrNoRows)
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "unauthorized",
+			id:       "1",
+			handler:  h.Reblog,
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodPost, "/v1/statuses/"+tt.id+"/reblog", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r = setChiURLParam(r, "id", tt.id)
+			if tt.isAuth {
+				testutil.SetAuth(r)
+			}
+			if tt.mockFunc != nil {
+				tt.mockFunc()
+			}
+			auth.Middleware(h.app)(tt.handler).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.check != nil {
+				var resp object.Status
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+				tt.check(t, resp)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFavouritedByHandler(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	mock.ExpectQuery("select \\* from status where id = \\?").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility"}).AddRow(1, 2, "test post", "public"))
+	mock.ExpectQuery("select account.\\* from account join favourite on account.id = favourite.account_id where favourite.status_id = \\? order by favourite.create_at desc limit \\?").
+		WithArgs(1, 40).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser").AddRow(3, "thirduser"))
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest(http.MethodGet, "/v1/statuses/1/favourited_by", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r = setChiURLParam(r, "id", "1")
+	h.FavouritedBy(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp []object.Account
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(resp))
+
+	mock.ExpectQuery("select \\* from status where id = \\?").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility"}).AddRow(1, 2, "test post", "public"))
+	mock.ExpectQuery("select account.\\* from account join favourite on account.id = favourite.account_id where favourite.status_id = \\? order by favourite.create_at desc limit \\?").
+		WithArgs(1, 40).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username"}))
+
+	w = httptest.NewRecorder()
+	h.FavouritedBy(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, "[]", w.Body.String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+const blockedQuery = "select target_account_id from block where account_id = \\? and target_account_id in"
+
+const insertStatusQuery = "insert into status \\(account_id, content, text, spoiler_text, sensitive, visibility, has_media, in_reply_to_id, in_reply_to_account_id, reblog_of_id\\) values \\(\\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?\\)"
+
+func newMockHandler(db *sql.DB) *handler {
+	return &handler{
+		app: &app.App{
+			Dao: dao.NewWithDB(sqlx.NewDb(db, "sqlmock")),
+		},
+	}
+}
+
+func setChiURLParam(r *http.Request, key, value string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+func newUint64(i uint64) *uint64 {
+	return &i
+}
+
+func newTime(t time.Time) *time.Time {
+	return &t
+}
+
+// The viewer blocks none of the authors
+func expectNoBlocks(mock sqlmock.Sqlmock, args ...driver.Value) {
+	mock.ExpectQuery(blockedQuery).
+		WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"target_account_id"}))
 }
@@ -0,0 +1,87 @@
+package statuses
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/presenter"
+	"yatter-backend-go/app/handler/request"
+
+	"github.com/pkg/errors"
+)
+
+type UpdateRequest struct {
+	Status string
+}
+
+// Handle request for `PUT /v1/statuses/{id}`
+func (h *handler) Update(w http.ResponseWriter, r *http.Request) {
+	account := auth.AccountOf(r)
+	if account == nil {
+		httperror.Error(w, http.StatusUnauthorized)
+		return
+	}
+	ctx := r.Context()
+
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	var req UpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	status, err := h.app.Dao.Status().Retrieve(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			httperror.NotFound(w, id)
+			return
+		}
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if status.AccountId != account.ID {
+		httperror.Error(w, http.StatusForbidden)
+		return
+	}
+	if status.ReblogOfID != nil {
+		httperror.BadRequest(w, errors.New("reblogs cannot be edited"))
+		return
+	}
+	if req.Status == "" && !status.HasMedia {
+		httperror.BadRequest(w, errors.New("status is required"))
+		return
+	}
+
+	if err := h.setText(ctx, status, req.Status); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if err := h.app.Dao.Status().Update(ctx, status); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	// edited_at を反映するため取り直す
+	status, err = h.app.Dao.Status().Retrieve(ctx, id)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if err := presenter.Status(ctx, h.app.Dao, account, status); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
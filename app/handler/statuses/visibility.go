@@ -0,0 +1,44 @@
+package statuses
+
+import (
+	"database/sql"
+	"net/http"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+	"yatter-backend-go/app/handler/visibility"
+)
+
+// Retrieve the status of path parameter `id`.
+// Statuses the viewer may not see are treated as not found so that their existence is not leaked.
+func (h *handler) retrieveVisible(w http.ResponseWriter, r *http.Request) (*object.Status, bool) {
+	ctx := r.Context()
+
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return nil, false
+	}
+
+	status, err := h.app.Dao.Status().Retrieve(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			httperror.NotFound(w, id)
+			return nil, false
+		}
+		httperror.InternalServerError(w, err)
+		return nil, false
+	}
+
+	visible, err := visibility.Filter(ctx, h.app.Dao, auth.AccountOf(r), []*object.Status{status})
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return nil, false
+	}
+	if len(visible) == 0 {
+		httperror.NotFound(w, id)
+		return nil, false
+	}
+	return status, true
+}
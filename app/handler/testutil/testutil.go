@@ -1 +1,34 @@
 package testutil
+
+import (
+	"net/http"
+	"time"
+
+	"yatter-backend-go/app/domain/object"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// Access token accepted by ExpectAuth
+const AccessToken = "test-access-token"
+
+// Set the bearer token header which auth.Middleware reads
+func SetAuth(r *http.Request) {
+	r.Header.Set("Authorization", "Bearer "+AccessToken)
+}
+
+// Expect the queries executed by auth.Middleware to resolve AccessToken to the account
+func ExpectAuth(mock sqlmock.Sqlmock, id object.AccountID, username string) {
+	ExpectAuthWithScopes(mock, id, username, object.FullScopes)
+}
+
+// Same as ExpectAuth, with AccessToken granted only scopes
+func ExpectAuthWithScopes(mock sqlmock.Sqlmock, id object.AccountID, username string, scopes string) {
+	mock.ExpectQuery("select \\* from access_token where token = \\?").
+		WithArgs(AccessToken).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "token", "scopes", "expires_at"}).
+			AddRow(1, id, AccessToken, scopes, time.Now().Add(time.Hour)))
+	mock.ExpectQuery("select \\* from account where id = \\?").
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(id, username))
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"yatter-backend-go/app/handler/auth"
 	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/presenter"
 	"yatter-backend-go/app/handler/request"
 )
 
@@ -27,6 +28,10 @@ func (h *handler) GetHome(w http.ResponseWriter, r *http.Request) {
 	if objStatuses, err := h.app.Dao.Status().HomeTimeline(ctx, account.ID, only_media, max_id, since_id, limit); err != nil {
 		httperror.InternalServerError(w, err)
 	} else if objStatuses != nil {
+		if err := presenter.Statuses(ctx, h.app.Dao, account, objStatuses); err != nil {
+			httperror.InternalServerError(w, err)
+			return
+		}
 		if err := json.NewEncoder(w).Encode(objStatuses); err != nil {
 			httperror.InternalServerError(w, err)
 		}
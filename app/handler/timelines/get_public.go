@@ -3,7 +3,9 @@ package timelines
 import (
 	"encoding/json"
 	"net/http"
+	"yatter-backend-go/app/handler/auth"
 	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/presenter"
 	"yatter-backend-go/app/handler/request"
 )
 
@@ -18,9 +20,13 @@ func (h *handler) GetPublic(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	if objStatuses, err := h.app.Dao.Status().PublicTimeline(ctx, only_media, max_id, since_id, limit); err != nil {
+	if objStatuses, err := h.app.Dao.Status().PublicTimeline(ctx, viewerIDOf(r), only_media, max_id, since_id, limit); err != nil {
 		httperror.InternalServerError(w, err)
 	} else if objStatuses != nil {
+		if err := presenter.Statuses(ctx, h.app.Dao, auth.AccountOf(r), objStatuses); err != nil {
+			httperror.InternalServerError(w, err)
+			return
+		}
 		if err := json.NewEncoder(w).Encode(objStatuses); err != nil {
 			httperror.InternalServerError(w, err)
 		}
@@ -0,0 +1,61 @@
+package timelines
+
+import (
+	"encoding/json"
+	"net/http"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/presenter"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Handler request for `GET /v1/timelines/tag/{hashtag}`
+func (h *handler) GetTag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	hashtag, err := request.HashtagOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	only_media, max_id, since_id, limit, err := request.ParseQueries(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	// パスのタグは any に含め、どれか1つが使われていればよい
+	filter := object.TagFilter{
+		Any:  append([]string{object.NormalizeTagName(hashtag)}, tagsQuery(r, "any")...),
+		All:  tagsQuery(r, "all"),
+		None: tagsQuery(r, "none"),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if objStatuses, err := h.app.Dao.Status().TagTimeline(ctx, viewerIDOf(r), filter, only_media, max_id, since_id, limit); err != nil {
+		httperror.InternalServerError(w, err)
+	} else if objStatuses != nil {
+		if err := presenter.Statuses(ctx, h.app.Dao, auth.AccountOf(r), objStatuses); err != nil {
+			httperror.InternalServerError(w, err)
+			return
+		}
+		if err := json.NewEncoder(w).Encode(objStatuses); err != nil {
+			httperror.InternalServerError(w, err)
+		}
+	} else {
+		httperror.NotFound(w, "timeline")
+	}
+}
+
+// Read tag names of query parameter key, given as `key[]=a&key[]=b` or `key=a`
+func tagsQuery(r *http.Request, key string) []string {
+	query := r.URL.Query()
+	var names []string
+	for _, name := range append(query[key+"[]"], query[key]...) {
+		if name = object.NormalizeTagName(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
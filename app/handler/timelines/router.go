@@ -3,6 +3,7 @@ package timelines
 import (
 	"net/http"
 	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/domain/object"
 	"yatter-backend-go/app/handler/auth"
 
 	"github.com/go-chi/chi"
@@ -17,7 +18,16 @@ func NewRouter(app *app.App) http.Handler {
 	r := chi.NewRouter()
 
 	h := &handler{app: app}
-	r.Get("/public", h.GetPublic)
-	r.With(auth.Middleware(app)).Get("/home", h.GetHome)
+	r.With(auth.OptionalMiddleware(app)).Get("/public", h.GetPublic)
+	r.With(auth.OptionalMiddleware(app)).Get("/tag/{hashtag}", h.GetTag)
+	r.With(auth.Middleware(app), auth.RequireScope(object.ScopeReadStatuses)).Get("/home", h.GetHome)
 	return r
 }
+
+// ID of the signed in account, nil for anonymous requests
+func viewerIDOf(r *http.Request) *object.AccountID {
+	if account := auth.AccountOf(r); account != nil {
+		return &account.ID
+	}
+	return nil
+}
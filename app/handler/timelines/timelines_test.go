@@ -9,6 +9,7 @@ import (
 	"yatter-backend-go/app/app"
 	"yatter-backend-go/app/dao"
 	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/testutil"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-chi/chi"
@@ -32,18 +33,30 @@ func TestGetPublic(t *testing.T) {
 			name:     "Success",
 			username: "testuser",
 			mockFunc: func() {
-				mock.ExpectQuery("select \\* from status order by create_at desc limit \\?").
+				mock.ExpectQuery("select \\* from status where visibility = 'public' AND reblog_of_id is null order by create_at desc limit \\?").
 					WithArgs(40).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content"}).
 						AddRow(1, 1, "test content").
 						AddRow(2, 1, "test content2"))
+				mock.ExpectQuery("select \\* from account where id in \\(\\?\\)").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
+				mock.ExpectQuery("select \\* from attachment where status_id in \\(\\?, \\?\\) order by id").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "status_id", "type", "url"}))
+				mock.ExpectQuery("select mention\\.status_id, mention\\.account_id, account\\.username from mention join account on account\\.id = mention\\.account_id where mention\\.status_id in \\(\\?, \\?\\) order by mention\\.id").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"status_id", "account_id", "username"}))
+				mock.ExpectQuery("select tag\\.\\*, status_tag\\.status_id from tag join status_tag on status_tag\\.tag_id = tag\\.id where status_tag\\.status_id in \\(\\?, \\?\\) order by status_tag\\.status_id, tag\\.name").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status_id"}))
 			},
 			wantCode: http.StatusOK,
 		},
 		{
 			name: "no timeline",
 			mockFunc: func() {
-				mock.ExpectQuery("select \\* from status order by create_at desc limit \\?").
+				mock.ExpectQuery("select \\* from status where visibility = 'public' AND reblog_of_id is null order by create_at desc limit \\?").
 					WithArgs(40).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content"}))
 			},
@@ -68,6 +81,29 @@ func TestGetPublic(t *testing.T) {
 	}
 }
 
+func TestGetPublicSignedIn(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	// ブロック・ミュートしているアカウントの投稿は除かれる
+	testutil.ExpectAuth(mock, 1, "testuser")
+	mock.ExpectQuery("select \\* from status where visibility = 'public' AND reblog_of_id is null AND account_id not in \\(select target_account_id from block where account_id = \\? union select target_account_id from mute where account_id = \\? and \\(mute.expires_at is null or mute.expires_at > \\?\\)\\) order by create_at desc limit \\?").
+		WithArgs(1, 1, sqlmock.AnyArg(), 40).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content"}))
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest(http.MethodGet, "/v1/timelines/public", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.SetAuth(r)
+	auth.OptionalMiddleware(h.app)(http.HandlerFunc(h.GetPublic)).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestGetHome(t *testing.T) {
 	db, mock := dao.NewMockDB()
 	h := newMockHandler(db)
@@ -84,14 +120,30 @@ func TestGetHome(t *testing.T) {
 			name:     "Success",
 			username: "testuser",
 			mockFunc: func() {
-				mock.ExpectQuery("select \\* from account where username = \\?").
-					WithArgs("testuser").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
-				mock.ExpectQuery("select status.\\* from status join relationship on status.account_id = relationship.follower_id where relationship.following_id = \\? order by status.create_at desc limit \\?").
-					WithArgs(1, 40).
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select status.\\* from status join relationship on status.account_id = relationship.follower_id where relationship.following_id = \\? and \\(status.visibility <> 'direct' or status.in_reply_to_account_id = \\? or exists \\(select 1 from mention where mention.status_id = status.id and mention.account_id = \\?\\)\\) and status.account_id not in \\(select target_account_id from block where account_id = \\? union select target_account_id from mute where account_id = \\? and \\(mute.expires_at is null or mute.expires_at > \\?\\)\\) and \\(status.reblog_of_id is null or status.reblog_of_id not in \\(select original.id from status as original where original.account_id in \\(select target_account_id from block where account_id = \\? union select target_account_id from mute where account_id = \\? and \\(mute.expires_at is null or mute.expires_at > \\?\\)\\)\\)\\) order by status.create_at desc limit \\?").
+					WithArgs(1, 1, 1, 1, 1, sqlmock.AnyArg(), 1, 1, sqlmock.AnyArg(), 40).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content"}).
 						AddRow(1, 1, "test content").
 						AddRow(2, 1, "test content2"))
+				mock.ExpectQuery("select \\* from account where id in \\(\\?\\)").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
+				mock.ExpectQuery("select \\* from attachment where status_id in \\(\\?, \\?\\) order by id").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "status_id", "type", "url"}))
+				mock.ExpectQuery("select mention\\.status_id, mention\\.account_id, account\\.username from mention join account on account\\.id = mention\\.account_id where mention\\.status_id in \\(\\?, \\?\\) order by mention\\.id").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"status_id", "account_id", "username"}))
+				mock.ExpectQuery("select tag\\.\\*, status_tag\\.status_id from tag join status_tag on status_tag\\.tag_id = tag\\.id where status_tag\\.status_id in \\(\\?, \\?\\) order by status_tag\\.status_id, tag\\.name").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status_id"}))
+				mock.ExpectQuery("select status_id from favourite where account_id = \\? and status_id in \\(\\?, \\?\\)").
+					WithArgs(1, 1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"status_id"}).AddRow(2))
+				mock.ExpectQuery("select reblog_of_id from status where account_id = \\? and reblog_of_id in \\(\\?, \\?\\)").
+					WithArgs(1, 1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"reblog_of_id"}))
 			},
 			isAuth:   true,
 			wantCode: http.StatusOK,
@@ -99,11 +151,9 @@ func TestGetHome(t *testing.T) {
 		{
 			name: "no timeline",
 			mockFunc: func() {
-				mock.ExpectQuery("select \\* from account where username = \\?").
-					WithArgs("testuser").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
-				mock.ExpectQuery("select status.\\* from status join relationship on status.account_id = relationship.follower_id where relationship.following_id = \\? order by status.create_at desc limit \\?").
-					WithArgs(1, 40).
+				testutil.ExpectAuth(mock, 1, "testuser")
+				mock.ExpectQuery("select status.\\* from status join relationship on status.account_id = relationship.follower_id where relationship.following_id = \\? and \\(status.visibility <> 'direct' or status.in_reply_to_account_id = \\? or exists \\(select 1 from mention where mention.status_id = status.id and mention.account_id = \\?\\)\\) and status.account_id not in \\(select target_account_id from block where account_id = \\? union select target_account_id from mute where account_id = \\? and \\(mute.expires_at is null or mute.expires_at > \\?\\)\\) and \\(status.reblog_of_id is null or status.reblog_of_id not in \\(select original.id from status as original where original.account_id in \\(select target_account_id from block where account_id = \\? union select target_account_id from mute where account_id = \\? and \\(mute.expires_at is null or mute.expires_at > \\?\\)\\)\\)\\) order by status.create_at desc limit \\?").
+					WithArgs(1, 1, 1, 1, 1, sqlmock.AnyArg(), 1, 1, sqlmock.AnyArg(), 40).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content"}))
 			},
 			isAuth:   true,
@@ -124,7 +174,7 @@ func TestGetHome(t *testing.T) {
 			}
 
 			if tt.isAuth {
-				r.Header.Set("Authentication", "username testuser")
+				testutil.SetAuth(r)
 			}
 
 			if tt.mockFunc != nil {
@@ -139,6 +189,76 @@ func TestGetHome(t *testing.T) {
 	}
 }
 
+func TestGetTag(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	taggedStatusIDs := "select status_tag\\.status_id from status_tag join tag on tag\\.id = status_tag\\.tag_id where tag\\.name in "
+
+	tests := []struct {
+		name     string
+		hashtag  string
+		query    string
+		mockFunc func()
+		wantCode int
+	}{
+		{
+			name:    "Success",
+			hashtag: "Go",
+			mockFunc: func() {
+				mock.ExpectQuery("select \\* from status where visibility = 'public' AND reblog_of_id is null AND id in \\("+taggedStatusIDs+"\\(\\?\\)\\) order by create_at desc limit \\?").
+					WithArgs("go", 40).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content"}).
+						AddRow(1, 1, "test content"))
+				mock.ExpectQuery("select \\* from account where id in \\(\\?\\)").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
+				mock.ExpectQuery("select \\* from attachment where status_id in \\(\\?\\) order by id").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "status_id", "type", "url"}))
+				mock.ExpectQuery("select mention\\.status_id, mention\\.account_id, account\\.username from mention join account on account\\.id = mention\\.account_id where mention\\.status_id in \\(\\?\\) order by mention\\.id").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"status_id", "account_id", "username"}))
+				mock.ExpectQuery("select tag\\.\\*, status_tag\\.status_id from tag join status_tag on status_tag\\.tag_id = tag\\.id where status_tag\\.status_id in \\(\\?\\) order by status_tag\\.status_id, tag\\.name").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status_id"}).AddRow(3, "go", 1))
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:    "any, all and none",
+			hashtag: "go",
+			query:   "?any[]=golang&any[]=%23Gopher&all=yatter&none[]=spam&max_id=10",
+			mockFunc: func() {
+				mock.ExpectQuery("select \\* from status where visibility = 'public' AND reblog_of_id is null AND id in \\("+taggedStatusIDs+"\\(\\?, \\?, \\?\\)\\) AND id in \\("+taggedStatusIDs+"\\(\\?\\)\\) AND id not in \\("+taggedStatusIDs+"\\(\\?\\)\\) AND id <= \\? order by create_at desc limit \\?").
+					WithArgs("go", "golang", "gopher", "yatter", "spam", 10, 40).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content"}))
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodGet, "/v1/timelines/tag/"+tt.hashtag+tt.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r = setChiURLParam(r, "hashtag", tt.hashtag)
+
+			if tt.mockFunc != nil {
+				tt.mockFunc()
+			}
+
+			auth.OptionalMiddleware(h.app)(http.HandlerFunc(h.GetTag)).ServeHTTP(w, r)
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func newMockHandler(db *sql.DB) *handler {
 	return &handler{
 		app: &app.App{
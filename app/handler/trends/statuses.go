@@ -0,0 +1,48 @@
+package trends
+
+import (
+	"encoding/json"
+	"net/http"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/presenter"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Handle request for `GET /v1/trends/statuses`
+func (h *handler) Statuses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit, err := request.ParseLimitQuery(r.URL.Query().Get("limit"))
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	// 公開範囲が public の投稿だけが集計されるため、公開範囲による絞り込みは不要
+	// ブロック・ミュートしているアカウントの投稿は除く
+	viewer := auth.AccountOf(r)
+	var viewerID *object.AccountID
+	if viewer != nil {
+		viewerID = &viewer.ID
+	}
+	statuses, err := h.app.Dao.Trend().Statuses(ctx, viewerID, limit)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if statuses == nil {
+		statuses = []*object.Status{}
+	}
+	if err := presenter.Statuses(ctx, h.app.Dao, viewer, statuses); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(statuses); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
@@ -0,0 +1,38 @@
+package trends
+
+import (
+	"encoding/json"
+	"net/http"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Handle request for `GET /v1/trends/tags`
+func (h *handler) Tags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit, err := request.ParseLimitQuery(r.URL.Query().Get("limit"))
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	tags, err := h.app.Dao.Trend().Tags(ctx, limit)
+	if err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+	if tags == nil {
+		tags = []*object.Tag{}
+	}
+	for _, tag := range tags {
+		tag.URL = object.TagURL(tag.Name)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(tags); err != nil {
+		httperror.InternalServerError(w, err)
+		return
+	}
+}
@@ -0,0 +1,143 @@
+package trends
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/testutil"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTags(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	mock.ExpectQuery("select tag\\.\\*, trend_tag\\.history from trend_tag join tag on tag\\.id = trend_tag\\.tag_id order by trend_tag\\.score desc, tag\\.id limit \\?").
+		WithArgs(40).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "history"}).
+			AddRow(1, "go", `[{"day":"2024-01-02T00:00:00Z","uses":3,"accounts":2},{"day":"2024-01-01T00:00:00Z","uses":0,"accounts":0}]`))
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest(http.MethodGet, "/v1/trends/tags", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Tags(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp []*object.Tag
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, resp, 1) {
+		assert.Equal(t, "go", resp[0].Name)
+		assert.Equal(t, "/v1/timelines/tag/go", resp[0].URL)
+		if assert.Len(t, resp[0].History, 2) {
+			assert.Equal(t, uint64(3), resp[0].History[0].Uses)
+			assert.Equal(t, uint64(2), resp[0].History[0].Accounts)
+		}
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatuses(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	h := newMockHandler(db)
+	defer db.Close()
+
+	tests := []struct {
+		name     string
+		isAuth   bool
+		mockFunc func()
+		wantIDs  []uint64
+	}{
+		{
+			name: "Success",
+			mockFunc: func() {
+				mock.ExpectQuery("select status\\.\\* from trend_status join status on status\\.id = trend_status\\.status_id order by trend_status\\.score desc, status\\.id desc limit \\?").
+					WithArgs(40).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility"}).
+						AddRow(2, 1, "<p>new</p>", "public").
+						AddRow(1, 1, "<p>old</p>", "public"))
+				mock.ExpectQuery("select \\* from account where id in \\(\\?\\)").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "testuser"))
+				mock.ExpectQuery("select \\* from attachment where status_id in \\(\\?, \\?\\) order by id").
+					WithArgs(2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "status_id", "type", "url"}))
+				mock.ExpectQuery("select mention\\.status_id, mention\\.account_id, account\\.username from mention join account on account\\.id = mention\\.account_id where mention\\.status_id in \\(\\?, \\?\\) order by mention\\.id").
+					WithArgs(2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"status_id", "account_id", "username"}))
+				mock.ExpectQuery("select tag\\.\\*, status_tag\\.status_id from tag join status_tag on status_tag\\.tag_id = tag\\.id where status_tag\\.status_id in \\(\\?, \\?\\) order by status_tag\\.status_id, tag\\.name").
+					WithArgs(2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status_id"}))
+			},
+			wantIDs: []uint64{2, 1},
+		},
+		{
+			name: "no trends",
+			mockFunc: func() {
+				mock.ExpectQuery("select status\\.\\* from trend_status join status on status\\.id = trend_status\\.status_id order by trend_status\\.score desc, status\\.id desc limit \\?").
+					WithArgs(40).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility"}))
+			},
+			wantIDs: []uint64{},
+		},
+		{
+			name:   "exclude blocked and muted accounts",
+			isAuth: true,
+			mockFunc: func() {
+				testutil.ExpectAuth(mock, 2, "viewer")
+				mock.ExpectQuery("select status\\.\\* from trend_status join status on status\\.id = trend_status\\.status_id where status\\.account_id not in \\(select target_account_id from block where account_id = \\? union select target_account_id from mute where account_id = \\? and .+\\) order by trend_status\\.score desc, status\\.id desc limit \\?").
+					WithArgs(2, 2, sqlmock.AnyArg(), 40).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "content", "visibility"}))
+			},
+			wantIDs: []uint64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodGet, "/v1/trends/statuses", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.isAuth {
+				testutil.SetAuth(r)
+			}
+			tt.mockFunc()
+			auth.OptionalMiddleware(h.app)(http.HandlerFunc(h.Statuses)).ServeHTTP(w, r)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			var resp []*object.Status
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			ids := []uint64{}
+			for _, status := range resp {
+				ids = append(ids, status.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func newMockHandler(db *sql.DB) *handler {
+	return &handler{
+		app: &app.App{
+			Dao: dao.NewWithDB(sqlx.NewDb(db, "sqlmock")),
+		},
+	}
+}
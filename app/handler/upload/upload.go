@@ -0,0 +1,49 @@
+package upload
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// File extensions of accepted image types
+var ImageTypes = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// File extensions of accepted video types
+var VideoTypes = map[string]string{
+	"video/mp4":  ".mp4",
+	"video/webm": ".webm",
+}
+
+// Uploaded file read into memory
+type File struct {
+	Content     []byte
+	ContentType string
+	Ext         string
+}
+
+// Read the uploaded file, rejecting it if larger than maxSize or not one of types
+func Read(r io.Reader, maxSize int64, types ...map[string]string) (*File, error) {
+	content, err := ioutil.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > maxSize {
+		return nil, errors.Errorf("file must be at most %d bytes", maxSize)
+	}
+
+	contentType := http.DetectContentType(content)
+	for _, t := range types {
+		if ext, ok := t[contentType]; ok {
+			return &File{Content: content, ContentType: contentType, Ext: ext}, nil
+		}
+	}
+	return nil, errors.Errorf("unsupported file type: %s", contentType)
+}
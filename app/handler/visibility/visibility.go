@@ -0,0 +1,124 @@
+package visibility
+
+import (
+	"context"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+)
+
+// Filter drops the statuses the viewer may not see: statuses of accounts the viewer is blocking,
+// reblogs of statuses by those accounts, followers-only statuses of accounts the viewer is not following and direct statuses not mentioning the viewer.
+// viewer may be nil for unauthenticated requests.
+func Filter(ctx context.Context, d dao.Dao, viewer *object.Account, statuses []*object.Status) ([]*object.Status, error) {
+	mentioned, err := mentioned(ctx, d, viewer, statuses)
+	if err != nil {
+		return nil, err
+	}
+	originalAuthors, err := originalAuthors(ctx, d, viewer, statuses)
+	if err != nil {
+		return nil, err
+	}
+	blocked, err := blocked(ctx, d, viewer, statuses, originalAuthors)
+	if err != nil {
+		return nil, err
+	}
+
+	following := make(map[object.AccountID]bool)
+	visible := make([]*object.Status, 0, len(statuses))
+	for _, status := range statuses {
+		if blocked[status.AccountId] {
+			continue
+		}
+		if status.ReblogOfID != nil && blocked[originalAuthors[*status.ReblogOfID]] {
+			continue
+		}
+		if status.Visibility == object.VisibilityPrivate && viewer != nil && viewer.ID != status.AccountId {
+			if _, ok := following[status.AccountId]; !ok {
+				isFollowing, err := d.Relationship().IsFollowing(ctx, viewer.ID, status.AccountId)
+				if err != nil {
+					return nil, err
+				}
+				following[status.AccountId] = isFollowing
+			}
+		}
+		if status.VisibleTo(viewer, following[status.AccountId], mentioned[status.ID]) {
+			visible = append(visible, status)
+		}
+	}
+	return visible, nil
+}
+
+// Look up which of the direct statuses mention the viewer, with one query
+func mentioned(ctx context.Context, d dao.Dao, viewer *object.Account, statuses []*object.Status) (map[uint64]bool, error) {
+	mentioned := make(map[uint64]bool)
+	if viewer == nil {
+		return mentioned, nil
+	}
+	var ids []uint64
+	for _, status := range statuses {
+		if status.Visibility == object.VisibilityDirect && status.AccountId != viewer.ID {
+			ids = append(ids, status.ID)
+		}
+	}
+	mentionedIDs, err := d.Mention().MentionedStatusIDs(ctx, viewer.ID, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range mentionedIDs {
+		mentioned[id] = true
+	}
+	return mentioned, nil
+}
+
+// Look up the authors of the reblogged statuses, keyed by the ID of the reblogged status
+func originalAuthors(ctx context.Context, d dao.Dao, viewer *object.Account, statuses []*object.Status) (map[uint64]object.AccountID, error) {
+	authors := make(map[uint64]object.AccountID)
+	if viewer == nil {
+		return authors, nil
+	}
+	var ids []uint64
+	for _, status := range statuses {
+		if status.ReblogOfID != nil {
+			ids = append(ids, *status.ReblogOfID)
+		}
+	}
+	originals, err := d.Status().RetrieveByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, original := range originals {
+		authors[original.ID] = original.AccountId
+	}
+	return authors, nil
+}
+
+// Returns the authors of statuses and reblogged statuses the viewer is blocking
+func blocked(ctx context.Context, d dao.Dao, viewer *object.Account, statuses []*object.Status, originalAuthors map[uint64]object.AccountID) (map[object.AccountID]bool, error) {
+	blocked := make(map[object.AccountID]bool)
+	if viewer == nil {
+		return blocked, nil
+	}
+	authors := make([]object.AccountID, 0, len(statuses)+len(originalAuthors))
+	for _, status := range statuses {
+		authors = append(authors, status.AccountId)
+	}
+	for _, author := range originalAuthors {
+		authors = append(authors, author)
+	}
+	var ids []object.AccountID
+	seen := make(map[object.AccountID]bool)
+	for _, author := range authors {
+		if author != viewer.ID && !seen[author] {
+			seen[author] = true
+			ids = append(ids, author)
+		}
+	}
+	blockedIDs, err := d.Block().BlockedAccountIDs(ctx, viewer.ID, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range blockedIDs {
+		blocked[id] = true
+	}
+	return blocked, nil
+}
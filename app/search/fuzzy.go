@@ -0,0 +1,59 @@
+package search
+
+// Check if query is word, or the beginning of word, with a few typos.
+// As many typos are allowed as Elasticsearch allows with fuzziness AUTO: none for up to 2 characters,
+// 1 for up to 5 characters and 2 for longer queries.
+func fuzzyMatch(query, word string) bool {
+	q, w := []rune(query), []rune(word)
+	typos := 2
+	switch {
+	case len(q) <= 2:
+		typos = 0
+	case len(q) <= 5:
+		typos = 1
+	}
+	if distance(q, w) <= typos {
+		return true
+	}
+	// 入力途中の語も探せるよう、同じ長さの先頭部分とも比べる
+	if len(w) > len(q) && distance(q, w[:len(q)]) <= typos {
+		return true
+	}
+	return false
+}
+
+// Optimal string alignment distance: how many insertions, deletions, substitutions
+// and transpositions of adjacent characters turn a into b
+func distance(a, b []rune) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
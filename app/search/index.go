@@ -0,0 +1,381 @@
+package search
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+)
+
+// Number of rows read at once while refreshing the index
+const refreshBatchSize = 1000
+
+// Search index held in the process, an alternative to the FULLTEXT indexes of MySQL.
+// Refresh rebuilds it from the database, so it lags behind by up to the interval between refreshes.
+// Results are read back from the database, so accounts and statuses deleted in the meantime are not returned.
+type Index struct {
+	dao dao.Dao
+
+	mu       sync.RWMutex
+	accounts []indexedAccount
+	statuses statusIndex
+	tags     []*object.Tag
+}
+
+var _ repository.Search = (*Index)(nil)
+
+type indexedAccount struct {
+	id             object.AccountID
+	username       string
+	displayName    string
+	followersCount uint64
+}
+
+type statusIndex struct {
+	// Lowercased text of the statuses by ID
+	texts map[uint64]string
+
+	// Authors of the statuses by ID
+	authors map[uint64]object.AccountID
+
+	// IDs of the statuses containing each bigram
+	bigrams map[string][]uint64
+}
+
+// Create an empty index of the database of d. It is filled by Refresh.
+func NewIndex(d dao.Dao) *Index {
+	return &Index{dao: d, statuses: newStatusIndex()}
+}
+
+func newStatusIndex() statusIndex {
+	return statusIndex{
+		texts:   make(map[uint64]string),
+		authors: make(map[uint64]object.AccountID),
+		bigrams: make(map[string][]uint64),
+	}
+}
+
+// Rebuild the index from the database
+func (x *Index) Refresh(ctx context.Context) error {
+	source := x.dao.SearchSource()
+
+	var accounts []indexedAccount
+	var afterAccountID object.AccountID
+	for {
+		batch, err := source.AccountsAfter(ctx, afterAccountID, refreshBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, account := range batch {
+			indexed := indexedAccount{
+				id:             account.ID,
+				username:       strings.ToLower(account.Username),
+				followersCount: account.FollowersCount,
+			}
+			if account.DisplayName != nil {
+				indexed.displayName = strings.ToLower(*account.DisplayName)
+			}
+			accounts = append(accounts, indexed)
+			afterAccountID = account.ID
+		}
+		if len(batch) < refreshBatchSize {
+			break
+		}
+	}
+
+	statuses := newStatusIndex()
+	var afterStatusID uint64
+	for {
+		batch, err := source.StatusesAfter(ctx, afterStatusID, refreshBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, status := range batch {
+			statuses.add(status)
+			afterStatusID = status.ID
+		}
+		if len(batch) < refreshBatchSize {
+			break
+		}
+	}
+
+	var tags []*object.Tag
+	var afterTagID uint64
+	for {
+		batch, err := source.TagsAfter(ctx, afterTagID, refreshBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, tag := range batch {
+			tags = append(tags, &object.Tag{ID: tag.ID, Name: tag.Name, CreateAt: tag.CreateAt})
+			afterTagID = tag.ID
+		}
+		if len(batch) < refreshBatchSize {
+			break
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.accounts = accounts
+	x.statuses = statuses
+	x.tags = tags
+	return nil
+}
+
+func (x *Index) Accounts(ctx context.Context, query string, offset, limit *uint64) ([]*object.Account, error) {
+	query = strings.ToLower(query)
+
+	type match struct {
+		account indexedAccount
+		rank    int
+	}
+	var matches []match
+	x.mu.RLock()
+	for _, account := range x.accounts {
+		if rank := accountRank(account, query); rank > 0 {
+			matches = append(matches, match{account: account, rank: rank})
+		}
+	}
+	x.mu.RUnlock()
+
+	// MySQL での検索と同じく、一致の仕方、フォロワー数、ID の順に並べる
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].rank != matches[j].rank {
+			return matches[i].rank > matches[j].rank
+		}
+		if matches[i].account.followersCount != matches[j].account.followersCount {
+			return matches[i].account.followersCount > matches[j].account.followersCount
+		}
+		return matches[i].account.id < matches[j].account.id
+	})
+	start, end := page(len(matches), offset, limit)
+	ids := make([]object.AccountID, 0, end-start)
+	for _, m := range matches[start:end] {
+		ids = append(ids, m.account.id)
+	}
+
+	accounts, err := x.dao.Account().RetrieveByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[object.AccountID]*object.Account, len(accounts))
+	for _, account := range accounts {
+		byID[account.ID] = account
+	}
+	entities := make([]*object.Account, 0, len(ids))
+	for _, id := range ids {
+		if account, ok := byID[id]; ok {
+			entities = append(entities, account)
+		}
+	}
+	return entities, nil
+}
+
+// How the account matches query: 4 for the exact username, 3 for a username starting with query,
+// 2 for a display name containing it, 1 for a fuzzy match and 0 if it does not match
+func accountRank(account indexedAccount, query string) int {
+	switch {
+	case account.username == query:
+		return 4
+	case strings.HasPrefix(account.username, query):
+		return 3
+	case account.displayName != "" && strings.Contains(account.displayName, query):
+		return 2
+	}
+	words := append([]string{account.username}, strings.Fields(account.displayName)...)
+	for _, word := range words {
+		if fuzzyMatch(query, word) {
+			return 1
+		}
+	}
+	return 0
+}
+
+func (x *Index) Statuses(ctx context.Context, viewerID *object.AccountID, query string, offset, limit *uint64) ([]*object.Status, error) {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return []*object.Status{}, nil
+	}
+
+	hidden := make(map[object.AccountID]bool)
+	if viewerID != nil {
+		ids, err := x.dao.SearchSource().HiddenAccountIDs(ctx, *viewerID)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			hidden[id] = true
+		}
+	}
+
+	type match struct {
+		id    uint64
+		score int
+	}
+	var matches []match
+	x.mu.RLock()
+	for _, id := range x.statuses.candidates(words) {
+		if hidden[x.statuses.authors[id]] {
+			continue
+		}
+		if score := x.statuses.score(id, words); score > 0 {
+			matches = append(matches, match{id: id, score: score})
+		}
+	}
+	x.mu.RUnlock()
+
+	// 語の出現回数が多い順、新しい順に並べる
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].id > matches[j].id
+	})
+	start, end := page(len(matches), offset, limit)
+	ids := make([]uint64, 0, end-start)
+	for _, m := range matches[start:end] {
+		ids = append(ids, m.id)
+	}
+
+	statuses, err := x.dao.Status().RetrieveByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint64]*object.Status, len(statuses))
+	for _, status := range statuses {
+		byID[status.ID] = status
+	}
+	entities := make([]*object.Status, 0, len(ids))
+	for _, id := range ids {
+		if status, ok := byID[id]; ok && status.Visibility == object.VisibilityPublic {
+			entities = append(entities, status)
+		}
+	}
+	return entities, nil
+}
+
+func (x *Index) Tags(ctx context.Context, query string, offset, limit *uint64) ([]*object.Tag, error) {
+	name := object.NormalizeTagName(query)
+
+	var matches []*object.Tag
+	x.mu.RLock()
+	for i := sort.Search(len(x.tags), func(i int) bool { return x.tags[i].Name >= name }); i < len(x.tags) && strings.HasPrefix(x.tags[i].Name, name); i++ {
+		tag := *x.tags[i]
+		matches = append(matches, &tag)
+	}
+	x.mu.RUnlock()
+
+	// 名前の順に並んでいるので、完全一致が先頭に来る
+	start, end := page(len(matches), offset, limit)
+	return matches[start:end], nil
+}
+
+// Range of the page of n results
+func page(n int, offset, limit *uint64) (int, int) {
+	start, end := 0, n
+	if limit == nil {
+		return start, end
+	}
+	if offset != nil {
+		start = int(min(*offset, uint64(n)))
+	}
+	end = int(min(uint64(start)+*limit, uint64(n)))
+	return start, end
+}
+
+func min(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func (s statusIndex) add(status *object.Status) {
+	text := strings.ToLower(status.Source)
+	s.texts[status.ID] = text
+	s.authors[status.ID] = status.AccountId
+	seen := make(map[string]bool)
+	for _, bigram := range bigrams(text) {
+		if !seen[bigram] {
+			seen[bigram] = true
+			s.bigrams[bigram] = append(s.bigrams[bigram], status.ID)
+		}
+	}
+}
+
+// IDs of the statuses which may contain all the words, narrowed down by the bigrams of the words
+func (s statusIndex) candidates(words []string) []uint64 {
+	var ids []uint64
+	narrowed := false
+	for _, word := range words {
+		for _, bigram := range bigrams(word) {
+			if !narrowed {
+				ids = s.bigrams[bigram]
+				narrowed = true
+			} else {
+				ids = intersect(ids, s.bigrams[bigram])
+			}
+			if len(ids) == 0 {
+				return nil
+			}
+		}
+	}
+	if narrowed {
+		return ids
+	}
+	// 1文字の語だけでは絞り込めないので全件を調べる
+	for id := range s.texts {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// How many times the words appear in the status, 0 if any of them does not
+func (s statusIndex) score(id uint64, words []string) int {
+	text := s.texts[id]
+	score := 0
+	for _, word := range words {
+		n := strings.Count(text, word)
+		if n == 0 {
+			return 0
+		}
+		score += n
+	}
+	return score
+}
+
+// Pairs of adjacent characters, as the ngram parser of MySQL splits text into
+func bigrams(text string) []string {
+	var bigrams []string
+	for _, word := range strings.Fields(text) {
+		runes := []rune(word)
+		for i := 0; i+1 < len(runes); i++ {
+			bigrams = append(bigrams, string(runes[i:i+2]))
+		}
+	}
+	return bigrams
+}
+
+// IDs in both of the ascending lists
+func intersect(a, b []uint64) []uint64 {
+	var ids []uint64
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			ids = append(ids, a[i])
+			i++
+			j++
+		case a[i] < b[j]:
+			i++
+		default:
+			j++
+		}
+	}
+	return ids
+}
@@ -0,0 +1,166 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockIndex(t *testing.T) (*Index, sqlmock.Sqlmock) {
+	db, mock := dao.NewMockDB()
+	t.Cleanup(func() { db.Close() })
+	index := NewIndex(dao.NewWithDB(sqlx.NewDb(db, "sqlmock")))
+
+	mock.ExpectQuery("select \\* from account where id > \\? order by id limit \\?").
+		WithArgs(0, refreshBatchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "display_name", "followers_count"}).
+			AddRow(1, "johnny", nil, 0).
+			AddRow(2, "john", nil, 0).
+			AddRow(3, "smith", "John Smith", 5).
+			AddRow(4, "jo_hn", nil, 0).
+			AddRow(5, "alice", nil, 0))
+	mock.ExpectQuery("select \\* from status where id > \\? and visibility = 'public' and reblog_of_id is null order by id limit \\?").
+		WithArgs(0, refreshBatchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "text"}).
+			AddRow(1, 1, "今日は良い天気です #yatter").
+			AddRow(2, 2, "天気が悪い、天気予報を見る").
+			AddRow(3, 5, "関係ない投稿"))
+	mock.ExpectQuery("select \\* from tag where id > \\? order by id limit \\?").
+		WithArgs(0, refreshBatchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "yatter").AddRow(2, "go").AddRow(3, "yatterapp"))
+	if err := index.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return index, mock
+}
+
+func TestIndexAccounts(t *testing.T) {
+	ctx := context.Background()
+	index, mock := newMockIndex(t)
+
+	// 完全一致、前方一致、表示名の部分一致、あいまいな一致の順に読み込む
+	mock.ExpectQuery("select \\* from account where id in \\(\\?, \\?, \\?, \\?\\)").
+		WithArgs(2, 1, 3, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).
+			AddRow(1, "johnny").AddRow(2, "john").AddRow(3, "smith").AddRow(4, "jo_hn"))
+	accounts, err := index.Accounts(ctx, "John", nil, nil)
+	assert.NoError(t, err)
+	var usernames []string
+	for _, account := range accounts {
+		usernames = append(usernames, account.Username)
+	}
+	assert.Equal(t, []string{"john", "johnny", "smith", "jo_hn"}, usernames)
+
+	// 綴りを誤っても見つかり、フォロワーの多い順に並ぶ
+	mock.ExpectQuery("select \\* from account where id in \\(\\?, \\?, \\?\\)").
+		WithArgs(3, 1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "johnny").AddRow(2, "john").AddRow(3, "smith"))
+	accounts, err = index.Accounts(ctx, "jhon", nil, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, accounts, 3) {
+		assert.Equal(t, "smith", accounts[0].Username)
+	}
+
+	limit, offset := uint64(1), uint64(1)
+	mock.ExpectQuery("select \\* from account where id in \\(\\?\\)").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(1, "johnny"))
+	accounts, err = index.Accounts(ctx, "john", &offset, &limit)
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 1)
+
+	// 一致しなければデータベースを読まない
+	accounts, err = index.Accounts(ctx, "bob", nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, accounts)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIndexStatuses(t *testing.T) {
+	ctx := context.Background()
+	index, mock := newMockIndex(t)
+
+	// 語の出現回数が多い順に並ぶ
+	mock.ExpectQuery("select \\* from status where id in \\(\\?, \\?\\)").
+		WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "visibility"}).
+			AddRow(1, 1, "public").AddRow(2, 2, "public"))
+	statuses, err := index.Statuses(ctx, nil, "天気", nil, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, statuses, 2) {
+		assert.Equal(t, uint64(2), statuses[0].ID)
+		assert.Equal(t, uint64(1), statuses[1].ID)
+	}
+
+	// ブロック・ミュートしているアカウントの投稿と、公開範囲が変わった投稿は返さない
+	viewerID := object.AccountID(5)
+	mock.ExpectQuery("select target_account_id from block where account_id = \\? union select target_account_id from mute where account_id = \\?").
+		WithArgs(5, 5, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"target_account_id"}).AddRow(2))
+	mock.ExpectQuery("select \\* from status where id in \\(\\?\\)").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "visibility"}).AddRow(1, 1, "private"))
+	statuses, err = index.Statuses(ctx, &viewerID, "天気", nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, statuses)
+
+	// すべての語を含む投稿だけを返す
+	mock.ExpectQuery("select \\* from status where id in \\(\\?\\)").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "visibility"}).AddRow(1, 1, "public"))
+	statuses, err = index.Statuses(ctx, nil, "天気 #Yatter", nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 1)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIndexTags(t *testing.T) {
+	ctx := context.Background()
+	index, mock := newMockIndex(t)
+
+	tags, err := index.Tags(ctx, "#Yatter", nil, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, tags, 2) {
+		assert.Equal(t, "yatter", tags[0].Name)
+		assert.Equal(t, "yatterapp", tags[1].Name)
+	}
+
+	limit := uint64(1)
+	tags, err = index.Tags(ctx, "ya", nil, &limit)
+	assert.NoError(t, err)
+	assert.Len(t, tags, 1)
+
+	tags, err = index.Tags(ctx, "rust", nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		query string
+		word  string
+		want  bool
+	}{
+		{"john", "john", true},
+		{"jhon", "john", true},
+		{"jon", "john", true},
+		{"jonh", "johnny", true},
+		{"jane", "john", false},
+		{"jo", "ja", false},
+		{"alexandra", "alexsandre", true},
+		{"alexandra", "alexis", false},
+		{"天気予報", "天気予想", true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, fuzzyMatch(tt.query, tt.word), "%s %s", tt.query, tt.word)
+	}
+}
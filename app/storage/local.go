@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Storage on the local filesystem which also serves the stored files
+type Local struct {
+	dir     string
+	baseURL string
+}
+
+var _ Storage = (*Local)(nil)
+
+// Create storage writing files under dir which are served below baseURL
+func NewLocal(dir, baseURL string) *Local {
+	return &Local{dir: dir, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+func (s *Local) Put(ctx context.Context, key string, content io.Reader, contentType string) (string, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return "", fmt.Errorf("create directory failed: %w", err)
+	}
+
+	f, err := os.Create(p)
+	if err != nil {
+		return "", fmt.Errorf("create file failed: %w", err)
+	}
+	if _, err := io.Copy(f, content); err != nil {
+		f.Close()
+		os.Remove(p)
+		return "", fmt.Errorf("write file failed: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("close file failed: %w", err)
+	}
+
+	return s.baseURL + "/" + key, nil
+}
+
+func (s *Local) Delete(ctx context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove file failed: %w", err)
+	}
+	return nil
+}
+
+// Path of baseURL which ServeHTTP is expected to be mounted at
+func (s *Local) MountPath() string {
+	u, err := url.Parse(s.baseURL)
+	if err != nil {
+		return s.baseURL
+	}
+	return u.Path
+}
+
+// Serve stored files.
+// Directories are not found so that the stored files cannot be listed.
+func (s *Local) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	http.StripPrefix(s.MountPath(), http.FileServer(filesOnly{http.Dir(s.dir)})).ServeHTTP(w, r)
+}
+
+// File system which hides directories
+type filesOnly struct {
+	fs http.FileSystem
+}
+
+func (fs filesOnly) Open(name string) (http.File, error) {
+	if strings.HasSuffix(name, "/") {
+		return nil, os.ErrNotExist
+	}
+	f, err := fs.fs.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if info.IsDir() {
+		f.Close()
+		return nil, os.ErrNotExist
+	}
+	return f, nil
+}
+
+func (s *Local) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if key == "" || cleaned != "/"+key {
+		return "", fmt.Errorf("invalid key: %s", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(cleaned)), nil
+}
@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocal(t *testing.T) {
+	ctx := context.Background()
+	s := NewLocal(t.TempDir(), "http://localhost:8080/media/")
+
+	url, err := s.Put(ctx, "accounts/avatars/1/a.png", strings.NewReader("content"), "image/png")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/media/accounts/avatars/1/a.png", url)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/media/accounts/avatars/1/a.png", nil)
+	s.ServeHTTP(w, r)
+	body, _ := ioutil.ReadAll(w.Body)
+	assert.Equal(t, "content", string(body))
+
+	// ディレクトリの一覧は返さない
+	for _, dir := range []string{"/media/", "/media/accounts", "/media/accounts/avatars/1/"} {
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, dir, nil))
+		assert.Equal(t, http.StatusNotFound, w.Code, dir)
+		assert.NotContains(t, w.Body.String(), "a.png", dir)
+	}
+
+	assert.NoError(t, s.Delete(ctx, "accounts/avatars/1/a.png"))
+	assert.NoError(t, s.Delete(ctx, "accounts/avatars/1/a.png"))
+
+	_, err = s.Put(ctx, "../escape", strings.NewReader("content"), "text/plain")
+	assert.Error(t, err)
+}
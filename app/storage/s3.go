@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	amzDateFormat  = "20060102T150405Z"
+	amzShortFormat = "20060102"
+)
+
+// Storage on an S3 compatible object storage, addressed in path style
+type S3 struct {
+	endpoint        string
+	region          string
+	bucket          string
+	accessKeyID     string
+	secretAccessKey string
+	publicURL       string
+	client          *http.Client
+	now             func() time.Time
+}
+
+var _ Storage = (*S3)(nil)
+
+type S3Config struct {
+	// e.g. https://s3.ap-northeast-1.amazonaws.com or http://minio:9000
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// URL prefix objects are served from, defaults to endpoint/bucket
+	PublicURL string
+}
+
+// Create storage uploading to the bucket of the S3 compatible endpoint
+func NewS3(cfg S3Config) *S3 {
+	endpoint := strings.TrimSuffix(cfg.Endpoint, "/")
+	publicURL := strings.TrimSuffix(cfg.PublicURL, "/")
+	if publicURL == "" {
+		publicURL = endpoint + "/" + cfg.Bucket
+	}
+
+	return &S3{
+		endpoint:        endpoint,
+		region:          cfg.Region,
+		bucket:          cfg.Bucket,
+		accessKeyID:     cfg.AccessKeyID,
+		secretAccessKey: cfg.SecretAccessKey,
+		publicURL:       publicURL,
+		client:          &http.Client{Timeout: 60 * time.Second},
+		now:             time.Now,
+	}
+}
+
+func (s *S3) Put(ctx context.Context, key string, content io.Reader, contentType string) (string, error) {
+	body, err := ioutil.ReadAll(content)
+	if err != nil {
+		return "", err
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", contentType)
+	if err := s.do(ctx, http.MethodPut, key, header, body); err != nil {
+		return "", err
+	}
+	return s.publicURL + "/" + escapePath(key), nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	return s.do(ctx, http.MethodDelete, key, http.Header{}, nil)
+}
+
+func (s *S3) do(ctx context.Context, method, key string, header http.Header, body []byte) error {
+	u, err := url.Parse(s.endpoint + "/" + escapePath(s.bucket+"/"+key))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header = header
+	s.sign(req, body)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("s3 %s failed: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("s3 %s %s failed with %d: %s", method, key, resp.StatusCode, msg)
+	}
+	return nil
+}
+
+// Sign the request with AWS Signature Version 4
+func (s *S3) sign(req *http.Request, body []byte) {
+	now := s.now().UTC()
+	amzDate := now.Format(amzDateFormat)
+	shortDate := now.Format(amzShortFormat)
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := shortDate + "/" + s.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signature := hex.EncodeToString(hmacSHA256(signingKey(s.secretAccessKey, shortDate, s.region, "s3"), stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKeyID, scope, signedHeaders, signature))
+}
+
+func signingKey(secret, date, region, service string) []byte {
+	k := hmacSHA256([]byte("AWS4"+secret), date)
+	k = hmacSHA256(k, region)
+	k = hmacSHA256(k, service)
+	return hmacSHA256(k, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// Escape every byte of the path except unreserved characters and `/` as SigV4 requires
+func escapePath(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"context"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigningKey(t *testing.T) {
+	// Example from the AWS Signature Version 4 documentation
+	key := signingKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	assert.Equal(t, "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d", hex.EncodeToString(key))
+}
+
+func TestS3(t *testing.T) {
+	ctx := context.Background()
+
+	var gotMethod, gotPath, gotAuth, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		gotMethod, gotPath, gotAuth, gotBody = r.Method, r.URL.EscapedPath(), r.Header.Get("Authorization"), string(body)
+	}))
+	defer server.Close()
+
+	s := NewS3(S3Config{
+		Endpoint:        server.URL,
+		Region:          "ap-northeast-1",
+		Bucket:          "yatter",
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "secret",
+		PublicURL:       "https://cdn.example.com/",
+	})
+	s.now = func() time.Time { return time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	url, err := s.Put(ctx, "media_attachments/1/a b.png", strings.NewReader("content"), "image/png")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/media_attachments/1/a%20b.png", url)
+	assert.Equal(t, http.MethodPut, gotMethod)
+	assert.Equal(t, "/yatter/media_attachments/1/a%20b.png", gotPath)
+	assert.Equal(t, "content", gotBody)
+	assert.True(t, strings.HasPrefix(gotAuth, "AWS4-HMAC-SHA256 Credential=AKID/20210102/ap-northeast-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature="))
+
+	assert.NoError(t, s.Delete(ctx, "media_attachments/1/a b.png"))
+	assert.Equal(t, http.MethodDelete, gotMethod)
+}
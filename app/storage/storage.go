@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// Blob storage for uploaded files
+type Storage interface {
+	// Store content under key and return the URL it is served from
+	Put(ctx context.Context, key string, content io.Reader, contentType string) (string, error)
+
+	// Remove the content stored under key
+	Delete(ctx context.Context, key string) error
+}
+
+// Build a unique key under prefix with the file extension ext
+func NewKey(prefix, ext string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate key failed: %w", err)
+	}
+	return prefix + "/" + hex.EncodeToString(b) + ext, nil
+}
@@ -0,0 +1,21 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"yatter-backend-go/app/dao"
+)
+
+// Delete the idempotency keys whose responses are no longer replayed
+func deleteExpiredIdempotencyKeys(ctx context.Context, d dao.Dao) error {
+	n, err := d.IdempotencyKey().DeleteExpired(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		log.Printf("[worker] deleted %d idempotency keys", n)
+	}
+	return nil
+}
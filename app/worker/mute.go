@@ -0,0 +1,21 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"yatter-backend-go/app/dao"
+)
+
+// Delete the mutes which have lapsed. They no longer take effect even before deleted.
+func deleteExpiredMutes(ctx context.Context, d dao.Dao) error {
+	n, err := d.Mute().DeleteExpired(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		log.Printf("[worker] deleted %d mutes", n)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"yatter-backend-go/app/dao"
+)
+
+// Mark the polls whose deadline has passed as expired
+func closeExpiredPolls(ctx context.Context, d dao.Dao) error {
+	n, err := d.Poll().CloseExpired(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		log.Printf("[worker] closed %d polls", n)
+	}
+	return nil
+}
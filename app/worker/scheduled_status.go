@@ -0,0 +1,134 @@
+package worker
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+)
+
+const (
+	// How long a scheduler may take to publish the statuses it claimed before others retry them
+	scheduledStatusLease = time.Minute
+
+	// Maximum number of scheduled statuses published in one run
+	scheduledStatusBatch = 20
+)
+
+// Publish the scheduled statuses whose time has come.
+// Each run claims them under a new lease token so that other server instances skip them.
+func publishScheduledStatuses(ctx context.Context, d dao.Dao) error {
+	token, err := newLeaseToken()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	claimed, err := d.ScheduledStatus().Claim(ctx, token, now, now.Add(scheduledStatusLease), scheduledStatusBatch)
+	if err != nil {
+		return err
+	}
+	for _, scheduled := range claimed {
+		// 1件の失敗で残りを止めない
+		err := publishScheduledStatus(ctx, d, scheduled)
+		var invalid *invalidScheduledStatusError
+		switch {
+		case err == nil:
+		case errors.As(err, &invalid):
+			// 再試行しても同じ理由で失敗するので、失敗を記録して取得されないようにする
+			log.Printf("[worker] scheduled status %d cannot be published: %v", scheduled.ID, invalid)
+			if _, err := d.ScheduledStatus().Fail(ctx, scheduled, invalid.Error(), time.Now()); err != nil {
+				log.Printf("[worker] record failure of scheduled status %d: %+v", scheduled.ID, err)
+			}
+		default:
+			// データベースの一時的な失敗などは、リースが切れた後に再試行される
+			log.Printf("[worker] publish scheduled status %d: %+v", scheduled.ID, err)
+		}
+	}
+	return nil
+}
+
+// Error of a scheduled status which fails the same way however many times it is retried
+type invalidScheduledStatusError struct {
+	err error
+}
+
+func (e *invalidScheduledStatusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *invalidScheduledStatusError) Unwrap() error {
+	return e.err
+}
+
+func publishScheduledStatus(ctx context.Context, d dao.Dao, scheduled *object.ScheduledStatus) error {
+	now := time.Now()
+	params := scheduled.Params
+
+	status := new(object.Status)
+	status.AccountId = scheduled.AccountID
+	// メンションは公開時点のアカウントで解決する
+	mentioned, err := d.Account().RetrieveByUsernames(ctx, object.ParseMentions(params.Text))
+	if err != nil {
+		return err
+	}
+	status.SetText(params.Text, mentioned)
+	status.Visibility = params.Visibility
+	status.Sensitive = params.Sensitive
+	if err := status.SetSpoilerText(params.SpoilerText); err != nil {
+		return &invalidScheduledStatusError{err}
+	}
+
+	// 返信先が予約中に削除された場合は通常の投稿として公開する
+	if params.InReplyToID != nil {
+		inReplyTo, err := d.Status().Retrieve(ctx, *params.InReplyToID)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		if inReplyTo != nil {
+			status.InReplyToID = &inReplyTo.ID
+			status.InReplyToAccountID = &inReplyTo.AccountId
+		}
+	}
+
+	attachments, err := d.Attachment().RetrieveByIDs(ctx, params.MediaIDs)
+	if err != nil {
+		return err
+	}
+	if len(attachments) != len(params.MediaIDs) {
+		return &invalidScheduledStatusError{errors.New("media attachments were deleted")}
+	}
+	status.MediaAttachments = attachments
+
+	if params.Poll != nil {
+		poll, err := object.NewPoll(params.Poll.Options, time.Duration(params.Poll.ExpiresIn)*time.Second, params.Poll.Multiple, params.Poll.HideTotals, now)
+		if err != nil {
+			return &invalidScheduledStatusError{err}
+		}
+		status.Poll = poll
+	}
+
+	published, err := d.ScheduledStatus().Publish(ctx, scheduled, status, now)
+	if err != nil {
+		return err
+	}
+	if !published {
+		log.Printf("[worker] scheduled status %d was cancelled or taken over", scheduled.ID)
+	}
+	return nil
+}
+
+// Generate a token identifying one claim of scheduled statuses
+func newLeaseToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,39 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+)
+
+// Maximum number of trending tags and statuses kept until the next run
+const maxTrends = 100
+
+// Recompute trending tags and statuses from the recent activity
+func refreshTrends(ctx context.Context, d dao.Dao) error {
+	now := time.Now()
+
+	activities, err := d.Trend().TagActivity(ctx, now, object.TrendHistoryDays)
+	if err != nil {
+		return err
+	}
+	tags := object.TagTrends(activities, now)
+	if len(tags) > maxTrends {
+		tags = tags[:maxTrends]
+	}
+	if err := d.Trend().ReplaceTags(ctx, tags); err != nil {
+		return err
+	}
+
+	interactions, err := d.Trend().StatusActivity(ctx, now.Add(-object.TrendWindow), now.Add(-object.MaxTrendingStatusAge))
+	if err != nil {
+		return err
+	}
+	statuses := object.StatusTrends(interactions, now)
+	if len(statuses) > maxTrends {
+		statuses = statuses[:maxTrends]
+	}
+	return d.Trend().ReplaceStatuses(ctx, statuses)
+}
@@ -0,0 +1,71 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/search"
+)
+
+const (
+	// Interval between runs of closing expired polls
+	closePollsInterval = time.Minute
+
+	// Interval between runs of publishing scheduled statuses
+	publishScheduledStatusesInterval = 10 * time.Second
+
+	// Interval between runs of deleting expired idempotency keys
+	deleteIdempotencyKeysInterval = 10 * time.Minute
+
+	// Interval between runs of recomputing trends
+	refreshTrendsInterval = 5 * time.Minute
+
+	// Interval between runs of deleting expired mutes
+	deleteMutesInterval = 10 * time.Minute
+
+	// Interval between runs of rebuilding the search index held in the process
+	refreshSearchIndexInterval = time.Minute
+)
+
+// Start the background jobs of the application. They stop when ctx is canceled.
+func Start(ctx context.Context, app *app.App) {
+	go every(ctx, closePollsInterval, "close expired polls", func(ctx context.Context) error {
+		return closeExpiredPolls(ctx, app.Dao)
+	})
+	go every(ctx, publishScheduledStatusesInterval, "publish scheduled statuses", func(ctx context.Context) error {
+		return publishScheduledStatuses(ctx, app.Dao)
+	})
+	go every(ctx, deleteIdempotencyKeysInterval, "delete expired idempotency keys", func(ctx context.Context) error {
+		return deleteExpiredIdempotencyKeys(ctx, app.Dao)
+	})
+	go every(ctx, refreshTrendsInterval, "refresh trends", func(ctx context.Context) error {
+		return refreshTrends(ctx, app.Dao)
+	})
+	go every(ctx, deleteMutesInterval, "delete expired mutes", func(ctx context.Context) error {
+		return deleteExpiredMutes(ctx, app.Dao)
+	})
+	// MySQL で検索する場合はインデックスがデータベースにあるので不要
+	if index, ok := app.Search.(*search.Index); ok {
+		go every(ctx, refreshSearchIndexInterval, "refresh search index", index.Refresh)
+	}
+}
+
+// Run job immediately and then every interval until ctx is canceled.
+// Errors are logged and do not stop the loop.
+func every(ctx context.Context, interval time.Duration, name string, job func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job(ctx); err != nil {
+			log.Printf("[worker] %s: %+v", name, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
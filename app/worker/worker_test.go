@@ -0,0 +1,212 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yatter-backend-go/app/dao"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloseExpiredPolls(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	defer db.Close()
+
+	mock.ExpectExec("update poll set expired = 1 where expired = 0 and expires_at <= \\?").
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err := closeExpiredPolls(context.Background(), dao.NewWithDB(sqlx.NewDb(db, "sqlmock")))
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteExpiredIdempotencyKeys(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	defer db.Close()
+
+	mock.ExpectExec("delete from idempotency_key where create_at <= \\?").
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	err := deleteExpiredIdempotencyKeys(context.Background(), dao.NewWithDB(sqlx.NewDb(db, "sqlmock")))
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteExpiredMutes(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	defer db.Close()
+
+	mock.ExpectExec("delete from mute where expires_at <= \\?").
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err := deleteExpiredMutes(context.Background(), dao.NewWithDB(sqlx.NewDb(db, "sqlmock")))
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshTrends(t *testing.T) {
+	db, mock := dao.NewMockDB()
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery("select tag\\.id as tag_id, tag\\.name, floor\\(timestampdiff\\(second, status\\.create_at, \\?\\) / \\?\\) as days_ago").
+		WithArgs(sqlmock.AnyArg(), 86400, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"tag_id", "name", "days_ago", "uses", "accounts"}).
+			AddRow(1, "go", 0, 3, 2).
+			AddRow(2, "usual", 0, 1, 1).
+			AddRow(2, "usual", 1, 6, 6))
+	mock.ExpectBegin()
+	mock.ExpectExec("delete from trend_tag").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("insert into trend_tag \\(tag_id, score, history\\) values \\(\\?, \\?, \\?\\)").
+		WithArgs(1, 2.0, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery("select status\\.id as status_id, status\\.create_at, count\\(\\*\\) as interactions").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"status_id", "create_at", "interactions"}).
+			AddRow(5, now.Add(-time.Hour), 2))
+	mock.ExpectBegin()
+	mock.ExpectExec("delete from trend_status").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("insert into trend_status \\(status_id, score\\) values \\(\\?, \\?\\)").
+		WithArgs(5, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := refreshTrends(context.Background(), dao.NewWithDB(sqlx.NewDb(db, "sqlmock")))
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPublishScheduledStatuses(t *testing.T) {
+	tests := []struct {
+		name      string
+		leaseHeld bool
+	}{
+		{name: "publish due status", leaseHeld: true},
+		{name: "skip status cancelled or taken over", leaseHeld: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dao.NewMockDB()
+			defer db.Close()
+
+			mock.ExpectExec("update scheduled_status set lease_token = \\?, lease_expires_at = \\? where scheduled_at <= \\? and failure is null and \\(lease_expires_at is null or lease_expires_at <= \\?\\) order by scheduled_at, id limit \\?").
+				WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), scheduledStatusBatch).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectQuery("select \\* from scheduled_status where lease_token = \\? order by scheduled_at, id").
+				WithArgs(sqlmock.AnyArg()).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "scheduled_at", "params", "lease_token", "lease_expires_at"}).
+					AddRow(7, 1, time.Now(), `{"text":"scheduled post","visibility":"unlisted","spoiler_text":"CW"}`, "token", time.Now().Add(time.Minute)))
+			mock.ExpectBegin()
+			if tt.leaseHeld {
+				mock.ExpectExec("delete from scheduled_status where id = \\? and lease_token = \\? and lease_expires_at > \\?").
+					WithArgs(7, "token", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("insert into status").
+					WithArgs(1, "<p>scheduled post</p>", "scheduled post", "CW", true, "unlisted", false, nil, nil, nil).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+				mock.ExpectExec("update status set thread_path = \\? where id = \\?").
+					WithArgs("3/", 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectExec("delete from scheduled_status where id = \\? and lease_token = \\? and lease_expires_at > \\?").
+					WithArgs(7, "token", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			}
+
+			err := publishScheduledStatuses(context.Background(), dao.NewWithDB(sqlx.NewDb(db, "sqlmock")))
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPublishScheduledStatusesRecordsFailure(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   string
+		mockFunc func(mock sqlmock.Sqlmock)
+		failure  string
+	}{
+		{
+			name:    "invalid poll",
+			params:  `{"text":"scheduled post","visibility":"public","poll":{"options":["only"],"expires_in":300}}`,
+			failure: "poll must have 2 to 4 options",
+		},
+		{
+			name:   "media deleted",
+			params: `{"text":"scheduled post","visibility":"public","media_ids":[3]}`,
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("select \\* from attachment where id in \\(\\?\\) order by id").
+					WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			failure: "media attachments were deleted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dao.NewMockDB()
+			defer db.Close()
+
+			mock.ExpectExec("update scheduled_status set lease_token = \\?, lease_expires_at = \\? where scheduled_at <= \\? and failure is null").
+				WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), scheduledStatusBatch).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectQuery("select \\* from scheduled_status where lease_token = \\? order by scheduled_at, id").
+				WithArgs(sqlmock.AnyArg()).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "scheduled_at", "params", "lease_token", "lease_expires_at"}).
+					AddRow(7, 1, time.Now(), tt.params, "token", time.Now().Add(time.Minute)))
+			if tt.mockFunc != nil {
+				tt.mockFunc(mock)
+			}
+			// 再試行せず、失敗を記録してリースを解放する
+			mock.ExpectExec("update scheduled_status set failure = \\?, lease_token = null, lease_expires_at = null where id = \\? and lease_token = \\? and lease_expires_at > \\?").
+				WithArgs(tt.failure, 7, "token", sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			err := publishScheduledStatuses(context.Background(), dao.NewWithDB(sqlx.NewDb(db, "sqlmock")))
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestEveryStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	runs := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go func() {
+		every(ctx, time.Hour, "test", func(ctx context.Context) error {
+			runs <- struct{}{}
+			return nil
+		})
+		close(done)
+	}()
+
+	// 最初の一回は起動直後に実行される
+	select {
+	case <-runs:
+	case <-time.After(time.Second):
+		t.Fatal("job was not run on start")
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker did not stop after cancel")
+	}
+}
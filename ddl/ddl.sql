@@ -8,18 +8,38 @@ CREATE TABLE `account` (
   `note` text,
   `following_count` bigint(20) NOT NULL DEFAULT 0,
   `followers_count` bigint(20) NOT NULL DEFAULT 0,
+  `locked` boolean NOT NULL DEFAULT false,
   `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
-  PRIMARY KEY (`id`)
+  PRIMARY KEY (`id`),
+  FULLTEXT KEY `idx_username_display_name` (`username`, `display_name`) WITH PARSER ngram
 );
 
 CREATE TABLE `status` (
   `id` bigint(20) NOT NULL AUTO_INCREMENT,
   `account_id` bigint(20) NOT NULL,
   `content` text NOT NULL,
+  `text` text NOT NULL,
+  `spoiler_text` varchar(255) NOT NULL DEFAULT '',
+  `sensitive` tinyint(1) NOT NULL DEFAULT 0,
+  `visibility` varchar(16) NOT NULL DEFAULT 'public',
+  `has_media` tinyint(1) NOT NULL DEFAULT 0,
+  `poll_id` bigint(20),
+  `in_reply_to_id` bigint(20),
+  `in_reply_to_account_id` bigint(20),
+  `thread_path` varchar(2048) CHARACTER SET ascii NOT NULL DEFAULT '',
+  `favourites_count` bigint(20) NOT NULL DEFAULT 0,
+  `reblog_of_id` bigint(20),
+  `reblogs_count` bigint(20) NOT NULL DEFAULT 0,
+  `edited_at` datetime,
   `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
   PRIMARY KEY (`id`),
   INDEX `idx_account_id` (`account_id`),
-  CONSTRAINT `fk_status_account_id` FOREIGN KEY (`account_id`) REFERENCES  `account` (`id`)
+  INDEX `idx_thread_path` (`thread_path`(255)),
+  FULLTEXT KEY `idx_text` (`text`) WITH PARSER ngram,
+  UNIQUE KEY `idx_account_id_reblog_of_id` (`account_id`, `reblog_of_id`),
+  CONSTRAINT `fk_status_account_id` FOREIGN KEY (`account_id`) REFERENCES  `account` (`id`),
+  CONSTRAINT `fk_status_in_reply_to_id` FOREIGN KEY (`in_reply_to_id`) REFERENCES `status` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `fk_status_reblog_of_id` FOREIGN KEY (`reblog_of_id`) REFERENCES `status` (`id`) ON DELETE CASCADE
 );
 
 CREATE TABLE relationship (
@@ -32,3 +52,257 @@ CREATE TABLE relationship (
   FOREIGN KEY (`following_id`) REFERENCES `account` (`id`) ON DELETE CASCADE,
   FOREIGN KEY (`follower_id`) REFERENCES `account` (`id`) ON DELETE CASCADE
 );
+
+CREATE TABLE `application` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) NOT NULL,
+  `website` text,
+  `redirect_uri` text NOT NULL,
+  `scopes` varchar(255) NOT NULL,
+  `client_id` varchar(64) NOT NULL UNIQUE,
+  `client_secret` varchar(64) NOT NULL,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`)
+);
+
+CREATE TABLE `access_token` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20) NOT NULL,
+  `application_id` bigint(20),
+  `token` varchar(255) NOT NULL UNIQUE,
+  `scopes` varchar(255) NOT NULL DEFAULT '',
+  `expires_at` datetime NOT NULL,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  INDEX `idx_account_id` (`account_id`),
+  CONSTRAINT `fk_access_token_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_access_token_application_id` FOREIGN KEY (`application_id`) REFERENCES `application` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `oauth_authorization_code` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `code` varchar(255) NOT NULL UNIQUE,
+  `application_id` bigint(20) NOT NULL,
+  `account_id` bigint(20) NOT NULL,
+  `redirect_uri` text NOT NULL,
+  `scopes` varchar(255) NOT NULL,
+  `code_challenge` varchar(255) NOT NULL DEFAULT '',
+  `code_challenge_method` varchar(16) NOT NULL DEFAULT '',
+  `expires_at` datetime NOT NULL,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  CONSTRAINT `fk_oauth_authorization_code_application_id` FOREIGN KEY (`application_id`) REFERENCES `application` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_oauth_authorization_code_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `scheduled_status` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20) NOT NULL,
+  `scheduled_at` datetime NOT NULL,
+  `params` text NOT NULL,
+  `lease_token` varchar(64) CHARACTER SET ascii,
+  `lease_expires_at` datetime,
+  `failure` varchar(255),
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  INDEX `idx_account_id` (`account_id`),
+  INDEX `idx_scheduled_at` (`scheduled_at`),
+  INDEX `idx_lease_token` (`lease_token`),
+  CONSTRAINT `fk_scheduled_status_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `attachment` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20) NOT NULL,
+  `status_id` bigint(20),
+  `scheduled_status_id` bigint(20),
+  `type` varchar(16) NOT NULL,
+  `url` text NOT NULL,
+  `description` text,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  INDEX `idx_account_id` (`account_id`),
+  INDEX `idx_status_id` (`status_id`),
+  INDEX `idx_scheduled_status_id` (`scheduled_status_id`),
+  CONSTRAINT `fk_attachment_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_attachment_status_id` FOREIGN KEY (`status_id`) REFERENCES `status` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `fk_attachment_scheduled_status_id` FOREIGN KEY (`scheduled_status_id`) REFERENCES `scheduled_status` (`id`) ON DELETE SET NULL
+);
+
+CREATE TABLE `favourite` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20) NOT NULL,
+  `status_id` bigint(20) NOT NULL,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_account_id_status_id` (`account_id`, `status_id`),
+  INDEX `idx_status_id` (`status_id`),
+  CONSTRAINT `fk_favourite_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`),
+  CONSTRAINT `fk_favourite_status_id` FOREIGN KEY (`status_id`) REFERENCES `status` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `status_edit` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `status_id` bigint(20) NOT NULL,
+  `content` text NOT NULL,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  INDEX `idx_status_id` (`status_id`),
+  CONSTRAINT `fk_status_edit_status_id` FOREIGN KEY (`status_id`) REFERENCES `status` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `poll` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20) NOT NULL,
+  `status_id` bigint(20) NOT NULL,
+  `expires_at` datetime NOT NULL,
+  `expired` tinyint(1) NOT NULL DEFAULT 0,
+  `multiple` tinyint(1) NOT NULL DEFAULT 0,
+  `hide_totals` tinyint(1) NOT NULL DEFAULT 0,
+  `votes_count` bigint(20) NOT NULL DEFAULT 0,
+  `voters_count` bigint(20) NOT NULL DEFAULT 0,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_status_id` (`status_id`),
+  INDEX `idx_expired_expires_at` (`expired`, `expires_at`),
+  CONSTRAINT `fk_poll_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_poll_status_id` FOREIGN KEY (`status_id`) REFERENCES `status` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `poll_option` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `poll_id` bigint(20) NOT NULL,
+  `position` int NOT NULL,
+  `title` varchar(255) NOT NULL,
+  `votes_count` bigint(20) NOT NULL DEFAULT 0,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_poll_id_position` (`poll_id`, `position`),
+  CONSTRAINT `fk_poll_option_poll_id` FOREIGN KEY (`poll_id`) REFERENCES `poll` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `poll_vote` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `poll_id` bigint(20) NOT NULL,
+  `account_id` bigint(20) NOT NULL,
+  `choice` int NOT NULL,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_poll_id_account_id_choice` (`poll_id`, `account_id`, `choice`),
+  INDEX `idx_account_id` (`account_id`),
+  CONSTRAINT `fk_poll_vote_poll_id` FOREIGN KEY (`poll_id`) REFERENCES `poll` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_poll_vote_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `idempotency_key` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20) NOT NULL,
+  `idempotency_key` varchar(255) NOT NULL,
+  `response` mediumtext,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_account_id_idempotency_key` (`account_id`, `idempotency_key`),
+  INDEX `idx_create_at` (`create_at`),
+  CONSTRAINT `fk_idempotency_key_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `mention` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `status_id` bigint(20) NOT NULL,
+  `account_id` bigint(20) NOT NULL,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_status_id_account_id` (`status_id`, `account_id`),
+  INDEX `idx_account_id` (`account_id`),
+  CONSTRAINT `fk_mention_status_id` FOREIGN KEY (`status_id`) REFERENCES `status` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_mention_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `tag` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) NOT NULL UNIQUE,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`)
+);
+
+CREATE TABLE `status_tag` (
+  `status_id` bigint(20) NOT NULL,
+  `tag_id` bigint(20) NOT NULL,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`status_id`, `tag_id`),
+  INDEX `idx_tag_id_status_id` (`tag_id`, `status_id`),
+  CONSTRAINT `fk_status_tag_status_id` FOREIGN KEY (`status_id`) REFERENCES `status` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_status_tag_tag_id` FOREIGN KEY (`tag_id`) REFERENCES `tag` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `trend_tag` (
+  `tag_id` bigint(20) NOT NULL,
+  `score` double NOT NULL,
+  `history` text NOT NULL,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`tag_id`),
+  INDEX `idx_score` (`score`),
+  CONSTRAINT `fk_trend_tag_tag_id` FOREIGN KEY (`tag_id`) REFERENCES `tag` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `trend_status` (
+  `status_id` bigint(20) NOT NULL,
+  `score` double NOT NULL,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`status_id`),
+  INDEX `idx_score` (`score`),
+  CONSTRAINT `fk_trend_status_status_id` FOREIGN KEY (`status_id`) REFERENCES `status` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `follow_request` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20) NOT NULL,
+  `target_account_id` bigint(20) NOT NULL,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_account_id_target_account_id` (`account_id`, `target_account_id`),
+  INDEX `idx_target_account_id` (`target_account_id`),
+  CONSTRAINT `fk_follow_request_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_follow_request_target_account_id` FOREIGN KEY (`target_account_id`) REFERENCES `account` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `block` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20) NOT NULL,
+  `target_account_id` bigint(20) NOT NULL,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_account_id_target_account_id` (`account_id`, `target_account_id`),
+  INDEX `idx_target_account_id` (`target_account_id`),
+  CONSTRAINT `fk_block_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_block_target_account_id` FOREIGN KEY (`target_account_id`) REFERENCES `account` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `mute` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20) NOT NULL,
+  `target_account_id` bigint(20) NOT NULL,
+  `hide_notifications` boolean NOT NULL DEFAULT true,
+  `expires_at` datetime,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_account_id_target_account_id` (`account_id`, `target_account_id`),
+  INDEX `idx_expires_at` (`expires_at`),
+  CONSTRAINT `fk_mute_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_mute_target_account_id` FOREIGN KEY (`target_account_id`) REFERENCES `account` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `notification` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20) NOT NULL,
+  `from_account_id` bigint(20) NOT NULL,
+  `type` varchar(32) NOT NULL,
+  `status_id` bigint(20),
+  `status_key` bigint(20) NOT NULL DEFAULT 0,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_account_id_from_account_id_type_status_key` (`account_id`, `from_account_id`, `type`, `status_key`),
+  INDEX `idx_from_account_id` (`from_account_id`),
+  CONSTRAINT `fk_notification_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_notification_from_account_id` FOREIGN KEY (`from_account_id`) REFERENCES `account` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_notification_status_id` FOREIGN KEY (`status_id`) REFERENCES `status` (`id`) ON DELETE CASCADE
+);
@@ -9,6 +9,7 @@ import (
 	"yatter-backend-go/app/app"
 	"yatter-backend-go/app/config"
 	"yatter-backend-go/app/handler"
+	"yatter-backend-go/app/worker"
 )
 
 func main() {
@@ -20,6 +21,8 @@ func serve(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	worker.Start(ctx, app)
+
 	addr := ":" + strconv.Itoa(config.Port())
 	log.Printf("Serve on http://%s", addr)
 